@@ -0,0 +1,167 @@
+package gochalk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Space around content in columns / lines. Negative values are treated as 0
+type Spacing struct {
+	Top, Right, Bottom, Left int
+}
+
+// Method to get a copy of spacing with negative sides set to 0
+func (spacing Spacing) clamped() Spacing {
+	return Spacing{Top: max(spacing.Top, 0), Right: max(spacing.Right, 0), Bottom: max(spacing.Bottom, 0), Left: max(spacing.Left, 0)}
+}
+
+// Box drawing a border around content, with an optional title in the top border and subtitle in the bottom border.
+// Width is measured ignoring escape sequences, so content can contain strings already styled using gochalk
+type Box struct {
+	Title    string
+	Subtitle string
+	// Position of title and subtitle within the border
+	TitleAlignment Alignment
+	// Space between border and content
+	Padding Spacing
+	// Space around the border
+	Margin Spacing
+	Border Border
+	// Styles for border characters, title / subtitle and content
+	BorderStyle *Chalk
+	TitleStyle  *Chalk
+	BodyStyle   *Chalk
+	// Total width including border and padding. 0 fits the content. Content wider than Width is wrapped
+	Width int
+}
+
+// Creates a new Box with the given title, rounded border and one column of padding on each side
+//
+//	box := gochalk.NewBox("Action required")
+//	box.BorderStyle = gochalk.NewStyle(gochalk.FgYellow)
+//	box.Println("Run " + gochalk.TextBold("make migrate") + " before deploying")
+func NewBox(title string) *Box {
+	return &Box{
+		Title:      title,
+		Padding:    Spacing{Left: 1, Right: 1},
+		Border:     BorderRounded,
+		TitleStyle: NewStyle(Bold),
+	}
+}
+
+// Method to print content rendered inside the box
+func (box *Box) Println(content string) {
+	fmt.Println(box.Render(content))
+}
+
+// Method to render content inside the box
+func (box *Box) Render(content string) string {
+	border := box.Border
+	spacing := box.Padding.clamped()
+	edges := StringWidth(border.Left) + StringWidth(border.Right)
+	padding := spacing.Left + spacing.Right
+
+	innerWidth := 0
+	if box.Width > 0 {
+		innerWidth = max(box.Width-edges, 1)
+		content = Wrap(content, max(innerWidth-padding, 1))
+	}
+
+	lines := strings.Split(reapplyStylesPerLine(content), "\n")
+	if innerWidth == 0 {
+		for _, line := range lines {
+			innerWidth = max(innerWidth, StringWidth(line)+padding)
+		}
+		for _, label := range []string{box.Title, box.Subtitle} {
+			if label != "" {
+				innerWidth = max(innerWidth, StringWidth(label)+4)
+			}
+		}
+	}
+
+	var rendered []string
+	if top := box.horizontalLine(innerWidth, border.TopLeft, border.Top, border.TopRight, box.Title); top != "" {
+		rendered = append(rendered, top)
+	}
+
+	emptyLine := strings.Repeat(" ", innerWidth)
+	for index := 0; index < spacing.Top; index++ {
+		rendered = append(rendered, box.bodyLine(emptyLine))
+	}
+	for _, line := range lines {
+		padded := strings.Repeat(" ", spacing.Left) + alignString(line, innerWidth-padding, AlignLeft) + strings.Repeat(" ", spacing.Right)
+		rendered = append(rendered, box.bodyLine(padded))
+	}
+	for index := 0; index < spacing.Bottom; index++ {
+		rendered = append(rendered, box.bodyLine(emptyLine))
+	}
+
+	if bottom := box.horizontalLine(innerWidth, border.BottomLeft, border.Bottom, border.BottomRight, box.Subtitle); bottom != "" {
+		rendered = append(rendered, bottom)
+	}
+
+	return box.applyMargin(rendered)
+}
+
+// Method to render a line of content between the side borders
+func (box *Box) bodyLine(line string) string {
+	return box.borderString(box.Border.Left) + overlayStyle(box.BodyStyle, line) + box.borderString(box.Border.Right)
+}
+
+// Method to render the top or bottom border with a label placed inside it.
+// Borders without a horizontal line show the label on its own line
+func (box *Box) horizontalLine(innerWidth int, left, fill, right, label string) string {
+	if fill == "" {
+		if label == "" {
+			return ""
+		}
+		return strings.Repeat(" ", StringWidth(box.Border.Left)) + overlayStyle(box.TitleStyle, label)
+	}
+	if label == "" {
+		return box.borderString(left + strings.Repeat(fill, innerWidth) + right)
+	}
+
+	label = Truncate(label, max(innerWidth-4, 1), "…")
+	space := innerWidth - StringWidth(label) - 2
+	before := 1
+	switch box.TitleAlignment {
+	case AlignCenter:
+		before = space / 2
+	case AlignRight:
+		before = space - 1
+	}
+	before = max(before, 0)
+
+	return box.borderString(left+strings.Repeat(fill, before)) + " " + overlayStyle(box.TitleStyle, label) + " " +
+		box.borderString(strings.Repeat(fill, max(space-before, 0))+right)
+}
+
+// Method to add margin lines and columns around rendered lines
+func (box *Box) applyMargin(lines []string) string {
+	margin := box.Margin.clamped()
+	left := strings.Repeat(" ", margin.Left)
+	right := strings.Repeat(" ", margin.Right)
+	for index, line := range lines {
+		lines[index] = left + line + right
+	}
+
+	var result []string
+	for index := 0; index < margin.Top; index++ {
+		result = append(result, "")
+	}
+	result = append(result, lines...)
+	for index := 0; index < margin.Bottom; index++ {
+		result = append(result, "")
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// Method to style border characters
+func (box *Box) borderString(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return overlayStyle(box.BorderStyle, value)
+}
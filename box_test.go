@@ -0,0 +1,86 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBox_Render(t *testing.T) {
+	box := NewBox("Release")
+	box.TitleStyle = nil
+	box.Subtitle = "v1.2"
+	box.TitleAlignment = AlignLeft
+
+	actualString := box.Render("Deployed " + Green("api") + "\nAll good")
+	expectedString := strings.Join([]string{
+		"╭─ Release ────╮",
+		"│ Deployed " + Green("api") + " │",
+		"│ All good     │",
+		"╰─ v1.2 ───────╯",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestBox_PaddingMarginAndWidth(t *testing.T) {
+	box := NewBox("")
+	box.Border = BorderASCII
+	box.Padding = Spacing{Top: 1, Left: 2, Right: 2}
+	box.Margin = Spacing{Top: 1, Left: 1}
+	box.Width = 12
+
+	actualString := box.Render("wrap this text")
+	expectedString := strings.Join([]string{
+		"",
+		" +----------+",
+		" |          |",
+		" |  wrap    |",
+		" |  this    |",
+		" |  text    |",
+		" +----------+",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestBox_NegativeSpacing(t *testing.T) {
+	box := NewBox("")
+	box.Border = BorderASCII
+	box.Padding = Spacing{Top: -1, Left: -3, Right: 1, Bottom: -2}
+	box.Margin = Spacing{Top: -1, Left: -2, Right: -1, Bottom: -5}
+
+	actualString := box.Render("hi")
+	expectedString := strings.Join([]string{
+		"+---+",
+		"|hi |",
+		"+---+",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestBox_Styles(t *testing.T) {
+	box := NewBox("Hi")
+	box.Border = BorderSingle
+	box.TitleAlignment = AlignCenter
+	box.BorderStyle = NewStyle(FgYellow)
+	box.BodyStyle = NewStyle(BgBlue)
+
+	actualString := box.Render("abcdefgh")
+	yellow := func(value string) string { return escapedStyle(FgYellow) + value + resetStyle }
+	expectedString := strings.Join([]string{
+		yellow("┌───") + " " + escapedStyle(Bold) + "Hi" + resetStyle + " " + yellow("───┐"),
+		yellow("│") + escapedStyle(BgBlue) + " abcdefgh " + resetStyle + yellow("│"),
+		yellow("└──────────┘"),
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
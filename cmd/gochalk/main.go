@@ -0,0 +1,279 @@
+// Command gochalk styles text from shell scripts using the gochalk library.
+//
+// Usage:
+//
+//	gochalk [flags] [text...]        style text, read from stdin when no text is given
+//	gochalk template [template...]   render a template such as '{red Error} {dim msg}'
+//	gochalk strip                    remove escape sequences from stdin
+//	gochalk html [flags]             convert styled text from stdin to HTML
+//	gochalk detect                   print the color level of stdout: none, 16, 256 or truecolor
+//
+// Colors are detected like the library does. NO_COLOR disables them and FORCE_COLOR (0-3) overrides detection,
+// which is needed when output is captured, e.g. msg=$(FORCE_COLOR=1 gochalk --fg red failed)
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/shashankbhat10/gochalk"
+)
+
+// Level of stdout, replaced in tests
+var detectColorLevel = gochalk.DetectColorLevel
+
+// Names of color levels printed by detect
+var colorLevelNames = map[gochalk.ColorLevel]string{
+	gochalk.ColorLevelNone:      "none",
+	gochalk.ColorLevel16:        "16",
+	gochalk.ColorLevel256:       "256",
+	gochalk.ColorLevelTrueColor: "truecolor",
+}
+
+const usage = `Usage:
+  gochalk [flags] [text...]        style text, read from stdin when no text is given
+  gochalk template [template...]   render a template such as '{red Error} {dim msg}'
+  gochalk strip                    remove escape sequences from stdin
+  gochalk html [flags]             convert styled text from stdin to HTML
+  gochalk detect                   print the color level of stdout: none, 16, 256 or truecolor
+
+Colors can be names such as red or brightblue, numbers from 0 to 255 or #rrggbb.
+NO_COLOR disables colors and FORCE_COLOR (0-3) overrides detection.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Method to run the command with args and return its exit code. 2 is returned for invalid usage
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	var err error
+	switch command {
+	case "template":
+		err = runTemplate(args[1:], stdin, stdout)
+	case "strip":
+		err = runStrip(args[1:], stdin, stdout, stderr)
+	case "html":
+		err = runHTML(args[1:], stdin, stdout, stderr)
+	case "detect":
+		err = runDetect(args[1:], stdout, stderr)
+	case "help":
+		fmt.Fprint(stdout, usage)
+	default:
+		err = runStyle(args, stdin, stdout, stderr)
+	}
+
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case err != nil:
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// Returned after flags failed to parse. The flag package already reported the problem
+var errUsage = errors.New("invalid usage")
+
+// Method to create a flag set for a command, printing usage to stderr
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		if hasFlags(flags) {
+			fmt.Fprintf(stderr, "\nFlags of %s:\n", name)
+			flags.PrintDefaults()
+		}
+	}
+
+	return flags
+}
+
+// Method to check if a flag set defines any flags
+func hasFlags(flags *flag.FlagSet) bool {
+	found := false
+	flags.VisitAll(func(*flag.Flag) { found = true })
+
+	return found
+}
+
+// Method to parse flags, turning errors other than -help into errUsage
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+
+	return nil
+}
+
+// Method to style text given as arguments, or each line of stdin
+func runStyle(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("gochalk", stderr)
+	foreground := flags.String("fg", "", "foreground `color`")
+	background := flags.String("bg", "", "background `color`")
+	style := flags.String("style", "", "`style` such as red.bold or \"bgblue white\", as used in templates")
+	noNewline := flags.Bool("n", false, "don't print a newline after text given as arguments")
+	attributes := []string{"bold", "dim", "italic", "underline", "blink", "inverse", "hidden", "strike"}
+	enabled := map[string]*bool{}
+	for _, attribute := range attributes {
+		enabled[attribute] = flags.Bool(attribute, false, attribute+" text")
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	var words []string
+	if *style != "" {
+		words = append(words, *style)
+	}
+	if *foreground != "" {
+		words = append(words, *foreground)
+	}
+	if *background != "" {
+		words = append(words, "bg"+*background)
+	}
+	for _, attribute := range attributes {
+		if *enabled[attribute] {
+			words = append(words, attribute)
+		}
+	}
+	chalk, err := gochalk.ParseStyle(strings.Join(words, " "), &gochalk.TemplateOptions{Level: detectColorLevel()})
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() > 0 {
+		text := chalk.ToString(strings.Join(flags.Args(), " "))
+		if !*noNewline {
+			text += "\n"
+		}
+		_, err := io.WriteString(stdout, text)
+		return err
+	}
+
+	return eachLine(stdin, stdout, func(line string) (string, error) {
+		return chalk.ToString(line), nil
+	})
+}
+
+// Method to render templates given as arguments, or each line of stdin
+func runTemplate(args []string, stdin io.Reader, stdout io.Writer) error {
+	options := &gochalk.TemplateOptions{Level: detectColorLevel()}
+	if len(args) > 0 {
+		text, err := gochalk.RenderTemplate(strings.Join(args, " "), options)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, text+"\n")
+		return err
+	}
+
+	return eachLine(stdin, stdout, func(line string) (string, error) {
+		return gochalk.RenderTemplate(line, options)
+	})
+}
+
+// Method to write stdin with escape sequences removed
+func runStrip(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("strip", stderr)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	input, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(stdout, gochalk.Strip(string(input)))
+	return err
+}
+
+// Method to write stdin converted to an HTML page, or only the converted text with -fragment
+func runHTML(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("html", stderr)
+	classes := flags.Bool("classes", false, "use CSS classes instead of inline styles")
+	fragment := flags.Bool("fragment", false, "write only the converted text, without the surrounding page")
+	title := flags.String("title", "", "`title` of the page")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	input, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	options := &gochalk.HTMLOptions{UseClasses: *classes}
+	body := gochalk.ANSIToHTML(string(input), options)
+	if *fragment {
+		_, err = io.WriteString(stdout, body)
+		return err
+	}
+
+	style := "body { background: #1e1e1e; color: #e5e5e5; }\n"
+	if *classes {
+		style += gochalk.HTMLStylesheet(options)
+	}
+	_, err = fmt.Fprintf(stdout, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n"+
+		"<style>\n%s</style>\n</head>\n<body>\n<pre>%s</pre>\n</body>\n</html>\n",
+		html.EscapeString(*title), style, body)
+	return err
+}
+
+// Method to print the color level detected for stdout
+func runDetect(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("detect", stderr)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(stdout, colorLevelNames[detectColorLevel()])
+	return err
+}
+
+// Method to transform each line of reader, keeping line endings
+func eachLine(reader io.Reader, writer io.Writer, transform func(line string) (string, error)) error {
+	buffered := bufio.NewReader(reader)
+	for {
+		line, readErr := buffered.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		if line != "" {
+			text, ending := strings.CutSuffix(line, "\n")
+			result := ""
+			if text != "" {
+				var err error
+				if result, err = transform(text); err != nil {
+					return err
+				}
+			}
+			if ending {
+				result += "\n"
+			}
+			if _, err := io.WriteString(writer, result); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shashankbhat10/gochalk"
+)
+
+// Method to run the command at level with stdin and return its exit code, stdout and stderr
+func runCommand(t *testing.T, level gochalk.ColorLevel, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	detect := detectColorLevel
+	detectColorLevel = func() gochalk.ColorLevel { return level }
+	defer func() { detectColorLevel = detect }()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunStyle(t *testing.T) {
+	_, actualString, _ := runCommand(t, gochalk.ColorLevel16, "", "--fg", "red", "--bold", "deploy", "failed")
+	expectedString := gochalk.NewStyle(gochalk.FgRed, gochalk.Bold).ToString("deploy failed") + "\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	_, actualString, _ = runCommand(t, gochalk.ColorLevel16, "one\n\ntwo", "--style", "green.underline", "--bg", "blue")
+	chalk := gochalk.NewStyle(gochalk.FgGreen, gochalk.Underlined, gochalk.BgBlue)
+	expectedString = chalk.ToString("one") + "\n\n" + chalk.ToString("two")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	_, actualString, _ = runCommand(t, gochalk.ColorLevelNone, "", "--fg", "#ff0000", "-n", "plain")
+	if expectedString := "plain"; strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestRunStyle_Errors(t *testing.T) {
+	code, _, stderr := runCommand(t, gochalk.ColorLevel16, "", "--fg", "shiny", "text")
+	if code != 1 || !strings.Contains(stderr, `unknown word "shiny"`) {
+		t.Errorf("Expected exit code 1 and an unknown word error, got %d and %q", code, stderr)
+	}
+
+	code, _, stderr = runCommand(t, gochalk.ColorLevel16, "", "--shiny", "text")
+	if code != 2 || !strings.Contains(stderr, "Usage:") {
+		t.Errorf("Expected exit code 2 and usage, got %d and %q", code, stderr)
+	}
+}
+
+func TestRunTemplate(t *testing.T) {
+	_, actualString, _ := runCommand(t, gochalk.ColorLevel16, "", "template", "{red Error} {dim msg}")
+	expectedString := gochalk.Red("Error") + " " + gochalk.NewStyle(gochalk.Dim).ToString("msg") + "\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	_, actualString, _ = runCommand(t, gochalk.ColorLevelNone, "{bold a}\n{red b}\n", "template")
+	if expectedString := "a\nb\n"; strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	code, _, stderr := runCommand(t, gochalk.ColorLevel16, "", "template", "{red Error")
+	if code != 1 || !strings.Contains(stderr, "unclosed") {
+		t.Errorf("Expected exit code 1 and an unclosed error, got %d and %q", code, stderr)
+	}
+}
+
+func TestRunStrip(t *testing.T) {
+	_, actualString, _ := runCommand(t, gochalk.ColorLevel16, gochalk.Red("failed")+" in 2s\n", "strip")
+	if expectedString := "failed in 2s\n"; strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestRunHTML(t *testing.T) {
+	input := gochalk.Red("Error") + " <x>\n"
+	_, actualString, _ := runCommand(t, gochalk.ColorLevel16, input, "html", "--fragment")
+	expectedString := gochalk.ANSIToHTML(input, nil)
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	_, actualString, _ = runCommand(t, gochalk.ColorLevel16, input, "html", "--title", "deploy <prod>")
+	for _, expected := range []string{"<!DOCTYPE html>", "<title>deploy &lt;prod&gt;</title>", "<pre>" + expectedString + "</pre>"} {
+		if !strings.Contains(actualString, expected) {
+			t.Errorf("Expected page to contain %q\nActual: %q", expected, actualString)
+		}
+	}
+}
+
+func TestRunDetect(t *testing.T) {
+	levels := map[gochalk.ColorLevel]string{
+		gochalk.ColorLevelNone:      "none\n",
+		gochalk.ColorLevel16:        "16\n",
+		gochalk.ColorLevel256:       "256\n",
+		gochalk.ColorLevelTrueColor: "truecolor\n",
+	}
+	for level, expectedString := range levels {
+		_, actualString, _ := runCommand(t, level, "", "detect")
+		if strings.Compare(actualString, expectedString) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package gochalk
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color represents a 24-bit RGB color
+type Color struct {
+	R, G, B uint8
+}
+
+// Palette holds the RGB values a terminal uses for the 16 basic colors.
+// Index 0-7 maps to black ... white and index 8-15 to the bright variants
+type Palette [16]Color
+
+// Default palette using the colors of xterm
+var DefaultPalette = Palette{
+	{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00}, {0xcd, 0xcd, 0x00},
+	{0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd}, {0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5},
+	{0x7f, 0x7f, 0x7f}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
+// Levels used by the 6x6x6 color cube of the 256 color range
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Method to return color in "#rrggbb" format
+func (color Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", color.R, color.G, color.B)
+}
+
+// Hue in degrees, saturation and lightness between 0 and 1
+type HSL struct {
+	H, S, L float64
+}
+
+// Hue in degrees, saturation and value between 0 and 1
+type HSV struct {
+	H, S, V float64
+}
+
+// Perceptual lightness between 0 and 1, chroma and hue in degrees of the OKLCH color space.
+// Equal steps in lightness look equally large, unlike in HSL
+type OKLCH struct {
+	L, C, H float64
+}
+
+// Method to parse a color in "#rrggbb" or "#rgb" format. The leading "#" is optional
+//
+//	brand, err := gochalk.ParseHex("#7c3aed")
+func ParseHex(value string) (Color, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	parsed, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return Color{}, fmt.Errorf("gochalk: invalid hex color %q", value)
+	}
+
+	return Color{uint8(parsed >> 16), uint8(parsed >> 8), uint8(parsed)}, nil
+}
+
+// Method to get a 24-bit foreground style for the color
+//
+//	gochalk.NewStyle(brand.Fg(), brand.Darken(0.3).Bg())
+func (color Color) Fg() Style {
+	return FgRGB(color.R, color.G, color.B)
+}
+
+// Method to get a 24-bit background style for the color
+func (color Color) Bg() Style {
+	return BgRGB(color.R, color.G, color.B)
+}
+
+// Method to convert the color to HSL
+func (color Color) HSL() HSL {
+	hue, saturation, lightness := rgbToHSL(color)
+	return HSL{hue, saturation, lightness}
+}
+
+// Method to convert the color to HSV
+func (color Color) HSV() HSV {
+	hue, _, _ := rgbToHSL(color)
+	high := max(color.R, color.G, color.B)
+	low := min(color.R, color.G, color.B)
+	if high == 0 {
+		return HSV{hue, 0, 0}
+	}
+
+	return HSV{hue, float64(high-low) / float64(high), float64(high) / 255}
+}
+
+// Method to convert the color to OKLCH
+func (color Color) OKLCH() OKLCH {
+	lightness, a, b := rgbToOKLab(color)
+	chroma := math.Hypot(a, b)
+	hue := math.Mod(math.Atan2(b, a)*180/math.Pi+360, 360)
+	// Hue of grays is noise from rounding
+	if chroma < 1e-4 {
+		chroma, hue = 0, 0
+	}
+
+	return OKLCH{lightness, chroma, hue}
+}
+
+// Method to convert HSL to RGB. Values outside the valid range are clamped
+func (hsl HSL) Color() Color {
+	return hslToRGB(hsl.H, hsl.S, hsl.L)
+}
+
+// Method to convert HSV to RGB. Values outside the valid range are clamped
+func (hsv HSV) Color() Color {
+	saturation := math.Min(math.Max(hsv.S, 0), 1)
+	value := math.Min(math.Max(hsv.V, 0), 1)
+
+	lightness := value * (1 - saturation/2)
+	hslSaturation := 0.0
+	if lightness > 0 && lightness < 1 {
+		hslSaturation = (value - lightness) / math.Min(lightness, 1-lightness)
+	}
+
+	return hslToRGB(hsv.H, hslSaturation, lightness)
+}
+
+// Method to convert OKLCH to RGB. Colors outside the sRGB range are clipped
+func (oklch OKLCH) Color() Color {
+	hue := oklch.H * math.Pi / 180
+	return okLabToRGB(oklch.L, oklch.C*math.Cos(hue), oklch.C*math.Sin(hue))
+}
+
+// Method to increase the HSL lightness by amount between 0 and 1
+//
+//	hover := brand.Lighten(0.1)
+func (color Color) Lighten(amount float64) Color {
+	hsl := color.HSL()
+	hsl.L += amount
+	return hsl.Color()
+}
+
+// Method to decrease the HSL lightness by amount between 0 and 1
+func (color Color) Darken(amount float64) Color {
+	return color.Lighten(-amount)
+}
+
+// Method to increase the HSL saturation by amount between 0 and 1
+func (color Color) Saturate(amount float64) Color {
+	hsl := color.HSL()
+	hsl.S += amount
+	return hsl.Color()
+}
+
+// Method to decrease the HSL saturation by amount between 0 and 1. Desaturate(1) gives a gray of equal lightness
+//
+//	disabled := brand.Desaturate(0.6)
+func (color Color) Desaturate(amount float64) Color {
+	return color.Saturate(-amount)
+}
+
+// Method to blend color with other. Weight between 0 and 1 is the share of other, so 0.5 gives the average
+//
+//	muted := brand.Mix(background, 0.7)
+func (color Color) Mix(other Color, weight float64) Color {
+	return interpolateColor(color, other, math.Min(math.Max(weight, 0), 1), InterpolateRGB)
+}
+
+// Method to get the color on the opposite side of the hue wheel
+func (color Color) Complement() Color {
+	hsl := color.HSL()
+	hsl.H += 180
+	return hsl.Color()
+}
+
+// Method to get the inverted color, e.g. white for black
+func (color Color) Invert() Color {
+	return Color{255 - color.R, 255 - color.G, 255 - color.B}
+}
+
+// Method to get the RGB value of a color from the 256 color range.
+// Index 0-15 is resolved using the palette, 16-231 from the color cube and 232-255 from the grayscale ramp
+func (palette *Palette) Color256(index uint8) Color {
+	switch {
+	case index < 16:
+		return palette[index]
+	case index < 232:
+		cube := index - 16
+		return Color{cubeLevels[cube/36], cubeLevels[(cube/6)%6], cubeLevels[cube%6]}
+	default:
+		gray := 8 + (index-232)*10
+		return Color{gray, gray, gray}
+	}
+}
+
+// Method to get the index of the closest color in the 256 color range, choosing between the color cube and the
+// grayscale ramp. The 16 basic colors are skipped since terminals use different values for them
+func nearestColor256(color Color) uint8 {
+	cubeIndex := func(value uint8) int {
+		best := 0
+		for index, level := range cubeLevels {
+			if absInt(int(value)-int(level)) < absInt(int(value)-int(cubeLevels[best])) {
+				best = index
+			}
+		}
+		return best
+	}
+	cube := uint8(16 + 36*cubeIndex(color.R) + 6*cubeIndex(color.G) + cubeIndex(color.B))
+
+	average := (int(color.R) + int(color.G) + int(color.B)) / 3
+	gray := uint8(232 + min(max((average-3)/10, 0), 23))
+
+	if colorDistance(color, DefaultPalette.Color256(gray)) < colorDistance(color, DefaultPalette.Color256(cube)) {
+		return gray
+	}
+	return cube
+}
+
+// Method to get the index of the closest of the 16 basic colors in palette
+func nearestColor16(color Color, palette *Palette) uint8 {
+	best := 0
+	for index := range palette {
+		if colorDistance(color, palette[index]) < colorDistance(color, palette[best]) {
+			best = index
+		}
+	}
+
+	return uint8(best)
+}
+
+// Method to get the squared distance between two colors, weighted for how sensitive the eye is to each channel
+func colorDistance(first, second Color) float64 {
+	meanRed := (float64(first.R) + float64(second.R)) / 2
+	red := float64(first.R) - float64(second.R)
+	green := float64(first.G) - float64(second.G)
+	blue := float64(first.B) - float64(second.B)
+
+	return (2+meanRed/256)*red*red + 4*green*green + (2+(255-meanRed)/256)*blue*blue
+}
+
+// Method to get the style showing color as foreground or background at the given color level.
+// Colors are downsampled to the closest 256 or basic color when true color isn't available.
+// Basic colors are matched using the palette set using SetPalette
+func colorStyle(color Color, level ColorLevel, background bool) Style {
+	switch {
+	case level >= ColorLevelTrueColor:
+		if background {
+			return BgRGB(color.R, color.G, color.B)
+		}
+		return FgRGB(color.R, color.G, color.B)
+	case level == ColorLevel256:
+		if background {
+			return Bg256(nearestColor256(color))
+		}
+		return Fg256(nearestColor256(color))
+	}
+
+	palette := CurrentPalette()
+	index := Style(nearestColor16(color, &palette))
+	base := FgBlack
+	if background {
+		base = BgBlack
+	}
+	if index >= 8 {
+		return base + 60 + index - 8
+	}
+	return base + index
+}
+
+// Method to get hue in degrees, saturation and lightness of a color
+func rgbToHSL(color Color) (hue, saturation, lightness float64) {
+	red, green, blue := float64(color.R)/255, float64(color.G)/255, float64(color.B)/255
+	high := math.Max(red, math.Max(green, blue))
+	low := math.Min(red, math.Min(green, blue))
+	lightness = (high + low) / 2
+	if high == low {
+		return 0, 0, lightness
+	}
+
+	delta := high - low
+	if lightness > 0.5 {
+		saturation = delta / (2 - high - low)
+	} else {
+		saturation = delta / (high + low)
+	}
+
+	switch high {
+	case red:
+		hue = math.Mod((green-blue)/delta+6, 6)
+	case green:
+		hue = (blue-red)/delta + 2
+	default:
+		hue = (red-green)/delta + 4
+	}
+
+	return hue * 60, saturation, lightness
+}
+
+// Method to get the color for a hue in degrees, saturation and lightness
+func hslToRGB(hue, saturation, lightness float64) Color {
+	hue = math.Mod(math.Mod(hue, 360)+360, 360)
+	saturation = math.Min(math.Max(saturation, 0), 1)
+	lightness = math.Min(math.Max(lightness, 0), 1)
+
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	second := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	var red, green, blue float64
+	switch {
+	case hue < 60:
+		red, green = chroma, second
+	case hue < 120:
+		red, green = second, chroma
+	case hue < 180:
+		green, blue = chroma, second
+	case hue < 240:
+		green, blue = second, chroma
+	case hue < 300:
+		red, blue = second, chroma
+	default:
+		red, blue = chroma, second
+	}
+
+	offset := lightness - chroma/2
+	return Color{unitToByte(red + offset), unitToByte(green + offset), unitToByte(blue + offset)}
+}
+
+// Method to convert a color to the OKLab color space, in which distances match perceived differences
+func rgbToOKLab(color Color) (lightness, a, b float64) {
+	red, green, blue := srgbToLinear(color.R), srgbToLinear(color.G), srgbToLinear(color.B)
+
+	long := math.Cbrt(0.4122214708*red + 0.5363325363*green + 0.0514459929*blue)
+	medium := math.Cbrt(0.2119034982*red + 0.6806995451*green + 0.1073969566*blue)
+	short := math.Cbrt(0.0883024619*red + 0.2817188376*green + 0.6299787005*blue)
+
+	return 0.2104542553*long + 0.7936177850*medium - 0.0040720468*short,
+		1.9779984951*long - 2.4285922050*medium + 0.4505937099*short,
+		0.0259040371*long + 0.7827717662*medium - 0.8086757660*short
+}
+
+// Method to convert an OKLab value to a color. Values outside the sRGB range are clipped
+func okLabToRGB(lightness, a, b float64) Color {
+	long := lightness + 0.3963377774*a + 0.2158037573*b
+	medium := lightness - 0.1055613458*a - 0.0638541728*b
+	short := lightness - 0.0894841775*a - 1.2914855480*b
+	long, medium, short = long*long*long, medium*medium*medium, short*short*short
+
+	return Color{
+		linearToSRGB(4.0767416621*long - 3.3077115913*medium + 0.2309699292*short),
+		linearToSRGB(-1.2684380046*long + 2.6097574011*medium - 0.3413193965*short),
+		linearToSRGB(-0.0041960863*long - 0.7034186147*medium + 1.7076147010*short),
+	}
+}
+
+// Method to convert a gamma encoded sRGB channel to linear light
+func srgbToLinear(value uint8) float64 {
+	channel := float64(value) / 255
+	if channel <= 0.04045 {
+		return channel / 12.92
+	}
+	return math.Pow((channel+0.055)/1.055, 2.4)
+}
+
+// Method to convert a linear light channel to a gamma encoded sRGB channel
+func linearToSRGB(value float64) uint8 {
+	if value <= 0.0031308 {
+		return unitToByte(value * 12.92)
+	}
+	return unitToByte(1.055*math.Pow(value, 1/2.4) - 0.055)
+}
+
+// Method to convert a channel in the range 0-1 to 0-255, clipping values outside the range
+func unitToByte(value float64) uint8 {
+	return uint8(math.Round(math.Min(math.Max(value, 0), 1) * 255))
+}
+
+// Method to get the absolute value of an int
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package gochalk
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := map[string]Color{
+		"#7c3aed": {0x7c, 0x3a, 0xed},
+		"7C3AED":  {0x7c, 0x3a, 0xed},
+		"#abc":    {0xaa, 0xbb, 0xcc},
+	}
+	for value, expected := range tests {
+		actual, err := ParseHex(value)
+		if err != nil || actual != expected {
+			t.Errorf("\nExpected: %v\nActual: %v (%v)", expected, actual, err)
+		}
+	}
+
+	for _, value := range []string{"", "#12345", "#ggg000", "#1234567", "+12345"} {
+		if _, err := ParseHex(value); err == nil {
+			t.Errorf("\nExpected: Error for %q\nActual: nil", value)
+		}
+	}
+}
+
+func TestColor_Styles(t *testing.T) {
+	color := Color{124, 58, 237}
+	if color.Fg() != FgRGB(124, 58, 237) || color.Bg() != BgRGB(124, 58, 237) {
+		t.Errorf("\nExpected: %d %d\nActual: %d %d", FgRGB(124, 58, 237), BgRGB(124, 58, 237), color.Fg(), color.Bg())
+	}
+
+	actualString := NewStyle(color.Fg()).ToString("brand")
+	expectedString := "\x1b[38;2;124;58;237mbrand\x1b[0m"
+	if actualString != expectedString {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestColor_Conversions(t *testing.T) {
+	if actual := (Color{255, 0, 0}).HSL(); actual != (HSL{0, 1, 0.5}) {
+		t.Errorf("\nExpected: %v\nActual: %v", HSL{0, 1, 0.5}, actual)
+	}
+	if actual := (HSL{120, 1, 0.25}).Color(); actual != (Color{0, 128, 0}) {
+		t.Errorf("\nExpected: %v\nActual: %v", Color{0, 128, 0}, actual)
+	}
+	if actual := (Color{0, 0, 255}).HSV(); actual != (HSV{240, 1, 1}) {
+		t.Errorf("\nExpected: %v\nActual: %v", HSV{240, 1, 1}, actual)
+	}
+	if actual := (HSV{0, 0.5, 1}).Color(); actual != (Color{255, 128, 128}) {
+		t.Errorf("\nExpected: %v\nActual: %v", Color{255, 128, 128}, actual)
+	}
+
+	white := Color{255, 255, 255}.OKLCH()
+	if math.Abs(white.L-1) > 1e-3 || white.C != 0 {
+		t.Errorf("\nExpected: L=1 C=0\nActual: %v", white)
+	}
+	red := Color{255, 0, 0}.OKLCH()
+	if math.Abs(red.L-0.628) > 1e-3 || math.Abs(red.C-0.258) > 1e-3 || math.Abs(red.H-29.23) > 0.1 {
+		t.Errorf("\nExpected: L=0.628 C=0.258 H=29.23\nActual: %v", red)
+	}
+
+	for _, color := range []Color{{0, 0, 0}, {255, 255, 255}, {124, 58, 237}, {18, 200, 86}, {250, 128, 3}} {
+		if actual := color.HSL().Color(); actual != color {
+			t.Errorf("\nExpected: %v\nActual: %v (HSL)", color, actual)
+		}
+		if actual := color.HSV().Color(); actual != color {
+			t.Errorf("\nExpected: %v\nActual: %v (HSV)", color, actual)
+		}
+		if actual := color.OKLCH().Color(); actual != color {
+			t.Errorf("\nExpected: %v\nActual: %v (OKLCH)", color, actual)
+		}
+	}
+}
+
+func TestColor_Manipulation(t *testing.T) {
+	red := Color{255, 0, 0}
+	gray := Color{128, 128, 128}
+
+	tests := []struct {
+		name     string
+		actual   Color
+		expected Color
+	}{
+		{"Lighten", Color{}.Lighten(0.5), gray},
+		{"Lighten clamped", red.Lighten(2), Color{255, 255, 255}},
+		{"Darken", red.Darken(0.25), Color{128, 0, 0}},
+		{"Desaturate", red.Desaturate(1), gray},
+		{"Saturate", Color{191, 64, 64}.Saturate(0.5), Color{255, 0, 0}},
+		{"Mix", Color{}.Mix(Color{255, 255, 255}, 0.5), gray},
+		{"Mix weight", red.Mix(Color{0, 0, 255}, 0.25), Color{191, 0, 64}},
+		{"Complement", red.Complement(), Color{0, 255, 255}},
+		{"Invert", Color{255, 128, 0}.Invert(), Color{0, 127, 255}},
+	}
+
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("\n%s\nExpected: %v\nActual: %v", test.name, test.expected, test.actual)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package gochalk
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Type of color vision deficiency
+type ColorBlindness int
+
+const (
+	// Missing red cones. Red looks dark and is confused with green
+	Protanopia ColorBlindness = iota
+	// Missing green cones, the most common type. Red and green are confused
+	Deuteranopia
+	// Missing blue cones. Blue is confused with green and yellow with violet
+	Tritanopia
+)
+
+// Matrices by Machado, Oliveira and Fernandes (2009) at full severity, applied to linear RGB
+var colorBlindnessMatrices = map[ColorBlindness][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// Replacements used in colorblind safe mode. Red becomes orange and green becomes blue
+var colorblindSafeStyles = map[Style]Style{
+	FgRed:         Fg256(208),
+	FgBrightRed:   Fg256(214),
+	FgGreen:       Fg256(33),
+	FgBrightGreen: Fg256(39),
+	BgRed:         Bg256(208),
+	BgBrightRed:   Bg256(214),
+	BgGreen:       Bg256(33),
+	BgBrightGreen: Bg256(39),
+}
+
+var colorblindSafe atomic.Bool
+
+// Method to enable or disable colorblind safe mode. While enabled the basic red and green styles, including the ones
+// used by Red, Green, RedBg and GreenBg, are written as orange and blue which can be told apart with any type of
+// color blindness. Other colors are unchanged
+//
+//	gochalk.SetColorblindSafe(os.Getenv("COLORBLIND") != "")
+//	fmt.Println(gochalk.Green("PASS"), gochalk.Red("FAIL"))
+func SetColorblindSafe(enabled bool) {
+	colorblindSafe.Store(enabled)
+}
+
+// Method to check if colorblind safe mode is enabled
+func ColorblindSafe() bool {
+	return colorblindSafe.Load()
+}
+
+// Method to get the replacement of a style in colorblind safe mode. Returns style unchanged when the mode is disabled
+func colorblindSafeStyle(style Style) Style {
+	if !colorblindSafe.Load() {
+		return style
+	}
+	if replacement, ok := colorblindSafeStyles[style]; ok {
+		return replacement
+	}
+
+	return style
+}
+
+// Method to get the color as seen with the given color vision deficiency
+func (color Color) Simulate(deficiency ColorBlindness) Color {
+	matrix, ok := colorBlindnessMatrices[deficiency]
+	if !ok {
+		return color
+	}
+
+	linear := [3]float64{srgbToLinear(color.R), srgbToLinear(color.G), srgbToLinear(color.B)}
+	var result [3]uint8
+	for row := range matrix {
+		result[row] = linearToSRGB(matrix[row][0]*linear[0] + matrix[row][1]*linear[1] + matrix[row][2]*linear[2])
+	}
+
+	return Color{result[0], result[1], result[2]}
+}
+
+// Method to get a Chalk with its colors as seen with the given color vision deficiency. Colors are replaced by
+// 24-bit styles, resolving basic and 256 colors using the palette set using SetPalette. Other styles are kept
+//
+//	pass, fail := gochalk.NewStyle(gochalk.FgGreen), gochalk.NewStyle(gochalk.FgRed)
+//	pass.Simulate(gochalk.Deuteranopia).Println("PASS")
+//	fail.Simulate(gochalk.Deuteranopia).Println("FAIL") // Hard to tell apart from PASS
+func (chalk *Chalk) Simulate(deficiency ColorBlindness) *Chalk {
+	palette := CurrentPalette()
+	styles := make([]Style, 0, len(chalk.styles))
+	for _, style := range chalk.styles {
+		if !isForeground(style) && !isBackground(style) {
+			styles = append(styles, style)
+			continue
+		}
+
+		var state sgrState
+		state.apply([]Style{style})
+		if isForeground(style) {
+			styles = append(styles, state.fg.resolve(&palette).Simulate(deficiency).Fg())
+		} else {
+			styles = append(styles, state.bg.resolve(&palette).Simulate(deficiency).Bg())
+		}
+	}
+	slices.Sort(styles)
+
+	newChalk := *chalk
+	newChalk.styles = styles
+	return &newChalk
+}
@@ -0,0 +1,61 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColor_Simulate(t *testing.T) {
+	tests := []struct {
+		deficiency ColorBlindness
+		color      Color
+		expected   Color
+	}{
+		{Protanopia, Color{205, 0, 0}, Color{86, 75, 0}},
+		{Deuteranopia, Color{205, 0, 0}, Color{130, 115, 0}},
+		{Deuteranopia, Color{0, 205, 0}, Color{192, 172, 45}},
+		{Tritanopia, Color{0, 0, 238}, Color{0, 100, 139}},
+		{Tritanopia, Color{255, 255, 255}, Color{255, 255, 255}},
+		{Protanopia, Color{0, 0, 0}, Color{0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		if actual := test.color.Simulate(test.deficiency); actual != test.expected {
+			t.Errorf("\nExpected: %v\nActual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestChalk_Simulate(t *testing.T) {
+	chalk := NewStyle(FgRed, BgRGB(0, 205, 0), Bold, Underlined)
+
+	actualString := chalk.Simulate(Deuteranopia).ToString("text")
+	expectedString := "\x1b[1;4;38;2;130;115;0;48;2;192;172;45mtext\x1b[0m"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = chalk.ToString("text")
+	expectedString = "\x1b[1;4;31;48;2;0;205;0mtext\x1b[0m"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSetColorblindSafe(t *testing.T) {
+	SetColorblindSafe(true)
+	defer SetColorblindSafe(false)
+
+	actualString := Green("PASS") + " " + Red("FAIL") + " " + StyledString("x", FgBrightRed, BgGreen, Bold) + " " + Yellow("WARN")
+	expectedString := "\x1b[38;5;33mPASS\x1b[0m \x1b[38;5;208mFAIL\x1b[0m \x1b[1;48;5;33;38;5;214mx\x1b[0m \x1b[33mWARN\x1b[0m"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	SetColorblindSafe(false)
+	actualString = Green("PASS")
+	expectedString = "\x1b[32mPASS\x1b[0m"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
@@ -0,0 +1,235 @@
+package gochalk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Colors used by GNU grep when GREP_COLORS isn't set
+const defaultGrepColors = "ms=01;31:mc=01;31:sl=:cx=:fn=35:ln=32:bn=32:se=36"
+
+// Styles used by grep for the parts of its output, as set by the GREP_COLORS environment variable
+type GrepColors struct {
+	// Matching text in selected and context lines
+	SelectedMatch *Chalk
+	ContextMatch  *Chalk
+	// Whole selected and context lines
+	SelectedLine *Chalk
+	ContextLine  *Chalk
+	FileName     *Chalk
+	LineNumber   *Chalk
+	ByteOffset   *Chalk
+	// Separators such as ":" and "--"
+	Separator *Chalk
+	// Set by "rv", swapping SelectedLine and ContextLine when grep runs with --invert-match
+	Reverse bool
+	// Set by "ne", disabling the erase to end of line sequences grep writes after colored text
+	NoErase bool
+}
+
+// Names of git colors and their foreground styles. Background styles are 10 higher
+var gitColorNames = map[string]Style{
+	"black": FgBlack, "red": FgRed, "green": FgGreen, "yellow": FgYellow,
+	"blue": FgBlue, "magenta": FgMagenta, "cyan": FgCyan, "white": FgWhite,
+	"brightblack": FgBrightBlack, "brightred": FgBrightRed, "brightgreen": FgBrightGreen, "brightyellow": FgBrightYellow,
+	"brightblue": FgBrightBlue, "brightmagenta": FgBrightMagenta, "brightcyan": FgBrightCyan, "brightwhite": FgBrightWhite,
+	"default": 39,
+}
+
+// Names of git attributes and their styles. Prefixing them with "no" or "no-" turns them off
+var gitAttributes = map[string][2]Style{
+	"bold": {Bold, 22}, "dim": {Dim, 22}, "italic": {Italics, 23}, "ul": {Underlined, 24},
+	"blink": {Blink, 25}, "reverse": {Inverse, 27}, "strike": {Strikethrough, 29},
+}
+
+// Method to parse the value of the GREP_COLORS environment variable, e.g. "ms=01;31:fn=35:ln=32".
+// Parts not set in value keep the defaults of grep. "mt" sets both match styles
+func ParseGrepColors(value string) *GrepColors {
+	colors := &GrepColors{}
+	for _, source := range []string{defaultGrepColors, value} {
+		for _, entry := range strings.Split(source, ":") {
+			key, style, _ := strings.Cut(entry, "=")
+			chalk := ParseSGR(style)
+			switch key {
+			case "mt":
+				colors.SelectedMatch, colors.ContextMatch = chalk, chalk
+			case "ms":
+				colors.SelectedMatch = chalk
+			case "mc":
+				colors.ContextMatch = chalk
+			case "sl":
+				colors.SelectedLine = chalk
+			case "cx":
+				colors.ContextLine = chalk
+			case "fn":
+				colors.FileName = chalk
+			case "ln":
+				colors.LineNumber = chalk
+			case "bn":
+				colors.ByteOffset = chalk
+			case "se":
+				colors.Separator = chalk
+			case "rv":
+				colors.Reverse = true
+			case "ne":
+				colors.NoErase = true
+			}
+		}
+	}
+
+	return colors
+}
+
+// Method to get the colors from the GREP_COLORS environment variable, or the defaults of grep if it isn't set
+//
+//	colors := gochalk.GrepColorsFromEnv()
+//	fmt.Println(colors.FileName.ToString(path) + colors.Separator.ToString(":") + colors.LineNumber.ToString("12"))
+func GrepColorsFromEnv() *GrepColors {
+	return ParseGrepColors(os.Getenv("GREP_COLORS"))
+}
+
+// Method to parse a git color value such as "red bold", "brightwhite #ff0000 ul" or "208 nobold".
+// The first color sets the foreground and the second the background. Colors can be names, numbers from the 256 color
+// range or "#rrggbb". "normal" leaves a color unchanged, "default" resets it and "reset" is ignored
+//
+//	old, err := gochalk.ParseGitColor("red bold")
+func ParseGitColor(value string) (*Chalk, error) {
+	var styles []Style
+	colors := 0
+	for _, word := range strings.Fields(strings.ToLower(value)) {
+		if color, ok := parseGitColorWord(word); ok {
+			if colors == 2 {
+				return nil, fmt.Errorf("gochalk: invalid git color %q: more than two colors", value)
+			}
+			if colors == 1 && color != 0 {
+				color = backgroundStyle(color)
+			}
+			if color != 0 {
+				styles = append(styles, color)
+			}
+			colors++
+			continue
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(word, "no"), "-")
+		attribute, known := gitAttributes[name]
+		switch {
+		case word == "reset":
+		case known && name != word:
+			styles = append(styles, attribute[1])
+		case known:
+			styles = append(styles, attribute[0])
+		default:
+			return nil, fmt.Errorf("gochalk: invalid git color %q: unknown word %q", value, word)
+		}
+	}
+
+	return NewStyle(styles...), nil
+}
+
+// Method to parse git color settings from a git config file or the output of "git config --list".
+// Returns styles of all color slots such as "color.diff.old", with section and key names lowercased.
+// Settings that aren't colors, such as "color.ui = auto", are skipped
+//
+//	theme, err := gochalk.ParseGitConfigColors(file)
+//	theme["color.diff.old"].Println("- removed line")
+func ParseGitConfigColors(reader io.Reader) (Theme, error) {
+	theme := Theme{}
+	section := ""
+
+	scanner := bufio.NewScanner(reader)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			header, _, found := strings.Cut(line[1:], "]")
+			if !found {
+				return nil, fmt.Errorf("gochalk: line %d: invalid section header", number)
+			}
+			name, subsection, hasSubsection := strings.Cut(strings.TrimSpace(header), " ")
+			section = strings.ToLower(name)
+			if hasSubsection {
+				section += "." + strings.Trim(strings.TrimSpace(subsection), `"`)
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if section != "" {
+			key = section + "." + key
+		}
+		value = strings.TrimSpace(stripGitComment(value))
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+
+		if !strings.HasPrefix(key, "color.") || strings.Count(key, ".") < 2 {
+			continue
+		}
+		chalk, err := ParseGitColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("gochalk: line %d: %w", number, err)
+		}
+		theme[key] = chalk
+	}
+
+	return theme, scanner.Err()
+}
+
+// Method to parse a git color name, number or "#rrggbb" value. Returns 0 for "normal"
+func parseGitColorWord(word string) (Style, bool) {
+	if word == "normal" || word == "-1" {
+		return 0, true
+	}
+	if style, ok := gitColorNames[word]; ok {
+		return style, true
+	}
+	if number, err := strconv.Atoi(word); err == nil && number >= 0 && number <= 255 {
+		return Fg256(uint8(number)), true
+	}
+	if strings.HasPrefix(word, "#") {
+		if color, err := ParseHex(word); err == nil {
+			return color.Fg(), true
+		}
+	}
+
+	return 0, false
+}
+
+// Method to get the background style matching a foreground style
+func backgroundStyle(style Style) Style {
+	switch style >> styleKindShift {
+	case styleKindFg256:
+		return Bg256(uint8(style & styleValueMask))
+	case styleKindFgRGB:
+		return styleRGB(style).Bg()
+	}
+
+	return style + 10
+}
+
+// Method to remove a trailing # or ; comment from a git config value. Comment characters inside quotes are kept
+func stripGitComment(value string) string {
+	quoted := false
+	for index, char := range value {
+		switch {
+		case char == '"' && (index == 0 || value[index-1] != '\\'):
+			quoted = !quoted
+		case (char == '#' || char == ';') && !quoted:
+			return value[:index]
+		}
+	}
+
+	return value
+}
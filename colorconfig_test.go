@@ -0,0 +1,108 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGrepColors(t *testing.T) {
+	colors := ParseGrepColors("mt=01;32:fn=34:se=:ne")
+
+	tests := []struct {
+		chalk    *Chalk
+		expected string
+	}{
+		{colors.SelectedMatch, "\x1b[1;32mtext\x1b[0m"},
+		{colors.ContextMatch, "\x1b[1;32mtext\x1b[0m"},
+		{colors.FileName, "\x1b[34mtext\x1b[0m"},
+		{colors.LineNumber, "\x1b[32mtext\x1b[0m"},
+		{colors.Separator, "text"},
+		{colors.SelectedLine, "text"},
+	}
+	for _, test := range tests {
+		actual := test.chalk.ToString("text")
+		if strings.Compare(actual, test.expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", test.expected, actual)
+		}
+	}
+
+	if !colors.NoErase || colors.Reverse {
+		t.Errorf("\nExpected: NoErase set and Reverse unset\nActual: %v %v", colors.NoErase, colors.Reverse)
+	}
+
+	actual := ParseGrepColors("").Separator.ToString(":")
+	expected := "\x1b[36m:\x1b[0m"
+	if strings.Compare(actual, expected) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expected, actual)
+	}
+}
+
+func TestParseGitColor(t *testing.T) {
+	tests := map[string]string{
+		"red bold":            "\x1b[1;31mtext\x1b[0m",
+		"brightwhite blue ul": "\x1b[4;44;97mtext\x1b[0m",
+		"normal red":          "\x1b[41mtext\x1b[0m",
+		"208 #ff0000":         "\x1b[38;5;208;48;2;255;0;0mtext\x1b[0m",
+		"default nobold":      "\x1b[22;39mtext\x1b[0m",
+		"reset no-italic":     "\x1b[23mtext\x1b[0m",
+		"":                    "text",
+	}
+	for value, expected := range tests {
+		chalk, err := ParseGitColor(value)
+		if err != nil {
+			t.Errorf("\nExpected: nil\nActual: %v", err)
+			continue
+		}
+		actual := chalk.ToString("text")
+		if strings.Compare(actual, expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q (%q)", expected, actual, value)
+		}
+	}
+
+	for _, value := range []string{"red green blue", "bolder", "256", "#12"} {
+		if _, err := ParseGitColor(value); err == nil {
+			t.Errorf("\nExpected: Error for %q\nActual: nil", value)
+		}
+	}
+}
+
+func TestParseGitConfigColors(t *testing.T) {
+	config := `[core]
+	editor = vim
+[color]
+	ui = auto
+[color "diff"]
+	old = red bold ; removed lines
+	New = "green"
+[color "status"]
+	untracked = "#ff8800"
+`
+	theme, err := ParseGitConfigColors(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+
+	expected := map[string]string{
+		"color.diff.old":         "\x1b[1;31mtext\x1b[0m",
+		"color.diff.new":         "\x1b[32mtext\x1b[0m",
+		"color.status.untracked": "\x1b[38;2;255;136;0mtext\x1b[0m",
+	}
+	if len(theme) != len(expected) {
+		t.Errorf("\nExpected: %d entries\nActual: %d (%v)", len(expected), len(theme), theme)
+	}
+	for key, value := range expected {
+		if chalk, ok := theme[key]; !ok || chalk.ToString("text") != value {
+			t.Errorf("\nExpected: %q for %s\nActual: %v", value, key, chalk)
+		}
+	}
+
+	theme, err = ParseGitConfigColors(strings.NewReader("color.diff.old=red\ncolor.branch.current=yellow reverse\n"))
+	if err != nil || theme["color.branch.current"].ToString("text") != "\x1b[7;33mtext\x1b[0m" {
+		t.Errorf("\nExpected: Settings from git config --list\nActual: %v %v", theme, err)
+	}
+
+	_, err = ParseGitConfigColors(strings.NewReader("[color \"diff\"]\nold = purple\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("\nExpected: Error on line 2\nActual: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package gochalk
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Named styles used by an application, e.g. "error", "warning" and "muted"
+type Theme map[string]*Chalk
+
+// WCAG conformance level used when checking contrast
+type ContrastLevel int
+
+const (
+	// Contrast of at least 4.5:1, or 3:1 for large text
+	ContrastAA ContrastLevel = iota
+	// Contrast of at least 7:1, or 4.5:1 for large text
+	ContrastAAA
+)
+
+// Options for checking contrast of styles
+type ContrastOptions struct {
+	Level ContrastLevel
+	// Use the lower thresholds for large or bold text
+	LargeText bool
+	// Colors assumed for styles that don't set a foreground or background. Default to palette white and black
+	Foreground *Color
+	Background *Color
+	// Palette used to resolve basic and 256 colors. Defaults to the palette set using SetPalette
+	Palette *Palette
+}
+
+// Theme entry failing the contrast check
+type ContrastIssue struct {
+	Name    string
+	Ratio   float64
+	Minimum float64
+	Level   ContrastLevel
+}
+
+// Method to return level as "AA" or "AAA"
+func (level ContrastLevel) String() string {
+	if level == ContrastAAA {
+		return "AAA"
+	}
+	return "AA"
+}
+
+// Method to get the minimum contrast ratio required by the level
+func (level ContrastLevel) MinimumRatio(largeText bool) float64 {
+	switch {
+	case level == ContrastAAA && largeText:
+		return 4.5
+	case level == ContrastAAA:
+		return 7
+	case largeText:
+		return 3
+	default:
+		return 4.5
+	}
+}
+
+// Method to describe the issue, e.g. `"warning": contrast 1.07:1 is below 4.5:1 (AA)`
+func (issue ContrastIssue) String() string {
+	return fmt.Sprintf("%q: contrast %.2f:1 is below %.1f:1 (%s)", issue.Name, issue.Ratio, issue.Minimum, issue.Level)
+}
+
+// Method to get the relative luminance of the color as defined by WCAG, from 0 for black to 1 for white
+func (color Color) Luminance() float64 {
+	return 0.2126*srgbToLinear(color.R) + 0.7152*srgbToLinear(color.G) + 0.0722*srgbToLinear(color.B)
+}
+
+// Method to get the WCAG contrast ratio between two colors, from 1 for equal colors to 21 for black on white
+//
+//	gochalk.ContrastRatio(gochalk.Color{255, 255, 255}, gochalk.Color{255, 255, 0}) // 1.07
+func ContrastRatio(first, second Color) float64 {
+	lighter, darker := first.Luminance(), second.Luminance()
+	if darker > lighter {
+		lighter, darker = darker, lighter
+	}
+
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+// Method to pick the candidate with the highest contrast against background. Picks black or white without candidates
+//
+//	background := gochalk.Color{255, 255, 0}
+//	gochalk.NewStyle(background.Bg(), gochalk.ReadableForeground(background).Fg())
+func ReadableForeground(background Color, candidates ...Color) Color {
+	if len(candidates) == 0 {
+		candidates = []Color{{0, 0, 0}, {255, 255, 255}}
+	}
+
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if ContrastRatio(candidate, background) > ContrastRatio(best, background) {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+// Method to get the contrast ratio between foreground and background of the chalk, with inverse applied.
+// Missing colors are assumed to be the palette white and black, as on a dark terminal.
+// Colors are resolved using the palette set using SetPalette
+//
+//	gochalk.NewStyle(gochalk.BgBrightYellow, gochalk.FgWhite).Contrast() // 1.17
+func (chalk *Chalk) Contrast() float64 {
+	return chalk.contrast(nil)
+}
+
+// Method to get the contrast ratio of the chalk using options for missing colors and palette
+func (chalk *Chalk) contrast(options *ContrastOptions) float64 {
+	options = options.withDefaults()
+
+	var state sgrState
+	state.apply(chalk.styles)
+	foreground, background := state.colors()
+
+	resolve := func(spec colorSpec, fallback Color) Color {
+		if spec.kind == colorDefault {
+			return fallback
+		}
+		return spec.resolve(options.Palette)
+	}
+
+	return ContrastRatio(resolve(foreground, *options.Foreground), resolve(background, *options.Background))
+}
+
+// Method to check each entry of theme against the WCAG thresholds of the level in options.
+// Returns entries below the threshold sorted by name. Entries without foreground or background color are skipped,
+// since they use the colors of the terminal. Passing nil options checks level AA for normal text
+//
+//	for _, issue := range gochalk.LintTheme(theme, &gochalk.ContrastOptions{Level: gochalk.ContrastAAA}) {
+//		fmt.Println(issue)
+//	}
+func LintTheme(theme Theme, options *ContrastOptions) []ContrastIssue {
+	options = options.withDefaults()
+	minimum := options.Level.MinimumRatio(options.LargeText)
+
+	var issues []ContrastIssue
+	for name, chalk := range theme {
+		if chalk == nil || !slices.ContainsFunc(chalk.styles, func(style Style) bool { return isForeground(style) || isBackground(style) }) {
+			continue
+		}
+
+		ratio := chalk.contrast(options)
+		// Round the same way as the message so a ratio shown as 4.50 never fails 4.5
+		if math.Round(ratio*100)/100 < minimum {
+			issues = append(issues, ContrastIssue{Name: name, Ratio: ratio, Minimum: minimum, Level: options.Level})
+		}
+	}
+
+	sort.Slice(issues, func(first, second int) bool {
+		return issues[first].Name < issues[second].Name
+	})
+
+	return issues
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *ContrastOptions) withDefaults() *ContrastOptions {
+	result := ContrastOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Palette == nil {
+		palette := CurrentPalette()
+		result.Palette = &palette
+	}
+	if result.Foreground == nil {
+		foreground := result.Palette[7]
+		result.Foreground = &foreground
+	}
+	if result.Background == nil {
+		background := result.Palette[0]
+		result.Background = &background
+	}
+
+	return &result
+}
@@ -0,0 +1,109 @@
+package gochalk
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		first, second Color
+		expected      float64
+	}{
+		{Color{0, 0, 0}, Color{255, 255, 255}, 21},
+		{Color{255, 255, 255}, Color{0, 0, 0}, 21},
+		{Color{255, 255, 255}, Color{255, 255, 0}, 1.07},
+		{Color{0x76, 0x76, 0x76}, Color{255, 255, 255}, 4.54},
+		{Color{18, 52, 86}, Color{18, 52, 86}, 1},
+	}
+
+	for _, test := range tests {
+		actual := ContrastRatio(test.first, test.second)
+		if math.Abs(actual-test.expected) > 0.005 {
+			t.Errorf("\nExpected: %.2f\nActual: %.2f (%v, %v)", test.expected, actual, test.first, test.second)
+		}
+	}
+}
+
+func TestChalk_Contrast(t *testing.T) {
+	tests := []struct {
+		chalk    *Chalk
+		expected float64
+	}{
+		{NewStyle(BgBrightYellow, FgWhite), 1.17},
+		{NewStyle(FgRed), 3.60},
+		{NewStyle(FgWhite, BgBlue), 7.46},
+		{NewStyle(Fg256(16), Bg256(231)), 21},
+		{NewStyle(FgRGB(255, 255, 255), BgRGB(255, 255, 0)), 1.07},
+		{NewStyle(Inverse), 16.67},
+	}
+
+	for _, test := range tests {
+		actual := test.chalk.Contrast()
+		if math.Abs(actual-test.expected) > 0.005 {
+			t.Errorf("\nExpected: %.2f\nActual: %.2f (%v)", test.expected, actual, test.chalk.styles)
+		}
+	}
+
+	light := Color{255, 255, 255}
+	actual := NewStyle(FgBrightYellow).contrast(&ContrastOptions{Background: &light})
+	if math.Abs(actual-1.07) > 0.005 {
+		t.Errorf("\nExpected: 1.07\nActual: %.2f", actual)
+	}
+}
+
+func TestReadableForeground(t *testing.T) {
+	black, white := Color{0, 0, 0}, Color{255, 255, 255}
+
+	if actual := ReadableForeground(Color{255, 255, 0}); actual != black {
+		t.Errorf("\nExpected: %v\nActual: %v", black, actual)
+	}
+	if actual := ReadableForeground(Color{0, 0, 0xee}); actual != white {
+		t.Errorf("\nExpected: %v\nActual: %v", white, actual)
+	}
+
+	brand := Color{124, 58, 237}
+	if actual := ReadableForeground(Color{250, 250, 250}, Color{200, 200, 200}, brand); actual != brand {
+		t.Errorf("\nExpected: %v\nActual: %v", brand, actual)
+	}
+}
+
+func TestLintTheme(t *testing.T) {
+	theme := Theme{
+		"error":   NewStyle(FgBrightRed, Bold),
+		"warning": NewStyle(BgBrightYellow, FgWhite),
+		"muted":   NewStyle(FgBrightBlack),
+		"link":    NewStyle(FgBlue),
+		"plain":   NewStyle(Bold),
+		"empty":   nil,
+	}
+
+	issues := LintTheme(theme, nil)
+	var names []string
+	for _, issue := range issues {
+		names = append(names, issue.Name)
+	}
+	actualString := strings.Join(names, ",")
+	expectedString := "link,warning"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+
+	actualString = issues[1].String()
+	expectedString = `"warning": contrast 1.17:1 is below 4.5:1 (AA)`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+
+	issues = LintTheme(theme, &ContrastOptions{Level: ContrastAAA})
+	names = nil
+	for _, issue := range issues {
+		names = append(names, issue.Name)
+	}
+	actualString = strings.Join(names, ",")
+	expectedString = "error,link,muted,warning"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
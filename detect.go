@@ -0,0 +1,134 @@
+package gochalk
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// -------------------------
+// Terminal capability detection
+// -------------------------
+
+// Method to check if file is connected to a terminal
+func isTerminal(file *os.File) bool {
+	if file == nil {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Method to check using environment variables if a terminal supports OSC 8 hyperlinks.
+// FORCE_HYPERLINK=1 / FORCE_HYPERLINK=0 overrides detection
+func detectHyperlinkSupport(getenv func(string) string, terminal bool) bool {
+	if force := getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0" && !strings.EqualFold(force, "false")
+	}
+	if !terminal || getenv("CI") != "" || getenv("TERM") == "dumb" {
+		return false
+	}
+
+	if getenv("WT_SESSION") != "" || getenv("KONSOLE_VERSION") != "" || getenv("DOMTERM") != "" {
+		return true
+	}
+	if version, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	switch getenv("TERM") {
+	case "xterm-kitty", "alacritty", "foot", "xterm-ghostty", "wezterm":
+		return true
+	}
+
+	return false
+}
+
+// Method to get the width of the terminal connected to stdout in columns.
+// Falls back to the COLUMNS environment variable and then to 80
+func TerminalWidth() int {
+	if isTerminal(os.Stdout) {
+		if columns := terminalColumns(os.Stdout); columns > 0 {
+			return columns
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return 80
+}
+
+// Number of colors a terminal can display
+type ColorLevel int
+
+const (
+	// Detect the level using DetectColorLevel. Only valid in options
+	ColorLevelAuto ColorLevel = iota
+	// No colors or styles
+	ColorLevelNone
+	// The 16 basic colors
+	ColorLevel16
+	// The 256 color range
+	ColorLevel256
+	// 24-bit RGB colors
+	ColorLevelTrueColor
+)
+
+// Method to get the number of colors supported by the terminal connected to stdout, using environment variables.
+// NO_COLOR disables colors and FORCE_COLOR (0-3) overrides detection, even when stdout isn't a terminal
+func DetectColorLevel() ColorLevel {
+	return detectColorLevel(os.Getenv, isTerminal(os.Stdout))
+}
+
+// Method to detect the color level of a terminal from environment variables
+func detectColorLevel(getenv func(string) string, terminal bool) ColorLevel {
+	if getenv("NO_COLOR") != "" {
+		return ColorLevelNone
+	}
+	if force := getenv("FORCE_COLOR"); force != "" {
+		switch strings.ToLower(force) {
+		case "0", "false":
+			return ColorLevelNone
+		case "2":
+			return ColorLevel256
+		case "3":
+			return ColorLevelTrueColor
+		default:
+			return ColorLevel16
+		}
+	}
+	if !terminal || getenv("TERM") == "dumb" {
+		return ColorLevelNone
+	}
+
+	term := getenv("TERM")
+	switch {
+	case getenv("COLORTERM") == "truecolor" || getenv("COLORTERM") == "24bit":
+		return ColorLevelTrueColor
+	case strings.HasSuffix(term, "-direct") || strings.Contains(term, "truecolor") || strings.Contains(term, "24bit"):
+		return ColorLevelTrueColor
+	case getenv("WT_SESSION") != "" || term == "xterm-kitty" || term == "xterm-ghostty" || term == "alacritty":
+		return ColorLevelTrueColor
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty":
+		return ColorLevelTrueColor
+	case "Apple_Terminal":
+		return ColorLevel256
+	}
+	if strings.Contains(term, "256color") {
+		return ColorLevel256
+	}
+
+	return ColorLevel16
+}
@@ -0,0 +1,674 @@
+package gochalk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Options for rendering diffs
+type DiffOptions struct {
+	// Names shown in the "---" / "+++" header by Diff. Default to "old" and "new"
+	OldName string
+	NewName string
+	// Unchanged lines shown around changes by Diff. Defaults to 3, a negative value shows none
+	ContextLines int
+	// Show old and new lines next to each other instead of one below the other
+	SideBySide bool
+	// Total width of side-by-side output. 0 uses the terminal width
+	Width int
+	// Styles for file headers, "@@" hunk headers, unchanged, removed and added lines
+	Header  *Chalk
+	Hunk    *Chalk
+	Context *Chalk
+	Removed *Chalk
+	Added   *Chalk
+	// Styles for the changed words inside a removed or added line
+	RemovedWord *Chalk
+	AddedWord   *Chalk
+	// Style for line numbers and the column separator of side-by-side output
+	LineNumber *Chalk
+}
+
+// Kind of an edit in a diff
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+// Single line or word of a diff
+type diffEdit struct {
+	op   diffOp
+	text string
+}
+
+// Kind of a line in unified diff text
+type diffLineKind int
+
+const (
+	diffLineHeader diffLineKind = iota
+	diffLineHunk
+	diffLineContext
+	diffLineRemoved
+	diffLineAdded
+	// "\ No newline at end of file"
+	diffLineNote
+)
+
+// Line of unified diff text with its line numbers and the parts changed compared to its paired line
+type diffLine struct {
+	kind     diffLineKind
+	text     string
+	oldLine  int
+	newLine  int
+	segments []diffSegment
+}
+
+// Part of a removed or added line. Changed parts are highlighted
+type diffSegment struct {
+	text    string
+	changed bool
+}
+
+// Smallest share of a line pair which must be unchanged for changed words to be highlighted.
+// Below this the lines are unrelated and highlighting every word would only add noise
+const diffWordSimilarity = 0.4
+
+// Method to diff two strings line by line and render the changes as a colored unified diff.
+// Passing nil options uses the default styles and 3 lines of context. Returns empty string if the strings are equal
+//
+//	fmt.Print(gochalk.Diff(expected, actual, &gochalk.DiffOptions{OldName: "expected", NewName: "actual"}))
+func Diff(oldText, newText string, options *DiffOptions) string {
+	options = options.withDefaults()
+
+	return ColorizeDiff(UnifiedDiff(options.OldName, options.NewName, oldText, newText, options.ContextLines), options)
+}
+
+// Method to diff two strings line by line using the Myers algorithm, returning the changes in unified diff format
+// without colors. Returns empty string if the strings are equal
+func UnifiedDiff(oldName, newName, oldText, newText string, contextLines int) string {
+	if oldText == newText {
+		return ""
+	}
+
+	edits := myersDiff(splitDiffLines(oldText), splitDiffLines(newText))
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", oldName, newName)
+	for _, hunk := range diffHunks(edits, max(contextLines, 0)) {
+		builder.WriteString(hunk)
+	}
+
+	return builder.String()
+}
+
+// Method to color unified diff text, as written by diff -u or git diff. Changed words of removed lines followed by
+// added lines are highlighted. Text outside of hunks is styled as headers. Passing nil options uses the default styles
+//
+//	output, _ := exec.Command("git", "diff").Output()
+//	fmt.Print(gochalk.ColorizeDiff(string(output), nil))
+func ColorizeDiff(diff string, options *DiffOptions) string {
+	options = options.withDefaults()
+	lines := parseDiffLines(diff)
+	highlightDiffWords(lines)
+
+	if options.SideBySide {
+		return options.renderSideBySide(lines)
+	}
+
+	rendered := make([]string, len(lines))
+	for index, line := range lines {
+		rendered[index] = options.renderLine(line)
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *DiffOptions) withDefaults() *DiffOptions {
+	result := DiffOptions{ContextLines: 3}
+	if options != nil {
+		result = *options
+		if result.ContextLines == 0 {
+			result.ContextLines = 3
+		}
+	}
+
+	if result.OldName == "" {
+		result.OldName = "old"
+	}
+	if result.NewName == "" {
+		result.NewName = "new"
+	}
+
+	fillDefaultStyles([]defaultStyle{
+		{&result.Header, NewStyle(Bold)},
+		{&result.Hunk, NewStyle(FgCyan)},
+		{&result.Removed, NewStyle(FgRed)},
+		{&result.Added, NewStyle(FgGreen)},
+		{&result.RemovedWord, NewStyle(FgBlack, BgRed)},
+		{&result.AddedWord, NewStyle(FgBlack, BgGreen)},
+		{&result.LineNumber, NewStyle(FgBrightBlack)},
+	})
+
+	return &result
+}
+
+// Method to render a line of unified diff output
+func (options *DiffOptions) renderLine(line diffLine) string {
+	switch line.kind {
+	case diffLineHeader:
+		return paintDiff(options.Header, line.text)
+	case diffLineHunk:
+		end := strings.Index(line.text[2:], "@@") + 4
+		return paintDiff(options.Hunk, line.text[:end]) + line.text[end:]
+	case diffLineRemoved:
+		return paintDiff(options.Removed, "-") + options.renderSegments(line)
+	case diffLineAdded:
+		return paintDiff(options.Added, "+") + options.renderSegments(line)
+	case diffLineNote:
+		return paintDiff(options.LineNumber, line.text)
+	default:
+		return paintDiff(options.Context, " "+line.text)
+	}
+}
+
+// Method to render the content of a removed or added line, highlighting changed words
+func (options *DiffOptions) renderSegments(line diffLine) string {
+	style, changedStyle := options.Removed, options.RemovedWord
+	if line.kind == diffLineAdded {
+		style, changedStyle = options.Added, options.AddedWord
+	}
+
+	var builder strings.Builder
+	for _, segment := range line.segments {
+		if segment.changed {
+			builder.WriteString(paintDiff(changedStyle, segment.text))
+		} else {
+			builder.WriteString(paintDiff(style, segment.text))
+		}
+	}
+
+	return builder.String()
+}
+
+// Method to render lines as two columns, old lines on the left and new lines on the right
+func (options *DiffOptions) renderSideBySide(lines []diffLine) string {
+	width := options.Width
+	if width <= 0 {
+		width = TerminalWidth()
+	}
+
+	numberWidth := 1
+	for index := range lines {
+		line := &lines[index]
+		numberWidth = max(numberWidth, len(strconv.Itoa(max(line.oldLine, line.newLine))))
+		// Tabs have no fixed width, so they would break the columns
+		line.text = strings.ReplaceAll(line.text, "\t", "    ")
+		for segment := range line.segments {
+			line.segments[segment].text = strings.ReplaceAll(line.segments[segment].text, "\t", "    ")
+		}
+	}
+	half := max((width-3)/2, numberWidth+3)
+	textWidth := half - numberWidth - 2
+	separator := paintDiff(options.LineNumber, " │ ")
+
+	cell := func(line *diffLine, number int) string {
+		if line == nil {
+			return strings.Repeat(" ", half)
+		}
+		marker, content := " ", paintDiff(options.Context, line.text)
+		switch line.kind {
+		case diffLineRemoved:
+			marker, content = paintDiff(options.Removed, "-"), options.renderSegments(*line)
+		case diffLineAdded:
+			marker, content = paintDiff(options.Added, "+"), options.renderSegments(*line)
+		}
+		return paintDiff(options.LineNumber, fmt.Sprintf("%*d", numberWidth, number)) + " " + marker +
+			alignString(Truncate(content, textWidth, "…"), textWidth, AlignLeft)
+	}
+
+	var rendered []string
+	for index := 0; index < len(lines); {
+		line := lines[index]
+		switch line.kind {
+		case diffLineContext:
+			rendered = append(rendered, cell(&lines[index], line.oldLine)+separator+cell(&lines[index], line.newLine))
+			index++
+		case diffLineRemoved, diffLineAdded:
+			var removed, added []*diffLine
+			for ; index < len(lines) && lines[index].kind == diffLineRemoved; index++ {
+				removed = append(removed, &lines[index])
+			}
+			for ; index < len(lines) && lines[index].kind == diffLineAdded; index++ {
+				added = append(added, &lines[index])
+			}
+			for pair := 0; pair < max(len(removed), len(added)); pair++ {
+				left, right := cell(nil, 0), cell(nil, 0)
+				if pair < len(removed) {
+					left = cell(removed[pair], removed[pair].oldLine)
+				}
+				if pair < len(added) {
+					right = cell(added[pair], added[pair].newLine)
+				}
+				rendered = append(rendered, left+separator+right)
+			}
+		default:
+			rendered = append(rendered, Truncate(options.renderLine(line), width, "…"))
+			index++
+		}
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+// Method to wrap non-empty text in styles of chalk
+func paintDiff(chalk *Chalk, text string) string {
+	if text == "" {
+		return ""
+	}
+	return overlayStyle(chalk, text)
+}
+
+// Method to split text into lines for diffing. Lines keep their newline, so a missing newline at the end is a change
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Method to find the shortest list of edits turning old into new using the Myers algorithm.
+// Common lines at the start and end are skipped first, which keeps typical diffs fast
+func myersDiff(old, new []string) []diffEdit {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+
+	var edits []diffEdit
+	for _, text := range old[:prefix] {
+		edits = append(edits, diffEdit{diffEqual, text})
+	}
+	edits = append(edits, myersMiddle(old[prefix:len(old)-suffix], new[prefix:len(new)-suffix])...)
+	for _, text := range old[len(old)-suffix:] {
+		edits = append(edits, diffEdit{diffEqual, text})
+	}
+
+	return edits
+}
+
+// State of the linear space Myers algorithm. Lines are compared as numbers, and the furthest reaching paths of the
+// forward and backward searches are shared by all recursive calls
+type myersDiffer struct {
+	old, new []string
+	a, b     []int
+	forward  []int
+	backward []int
+	offset   int
+	edits    []diffEdit
+}
+
+// Method to run the Myers algorithm in linear space, splitting the edit graph at the middle snake of each part.
+// Inputs without any common line are a single delete and insert, without searching
+func myersMiddle(old, new []string) []diffEdit {
+	if len(old)+len(new) == 0 {
+		return nil
+	}
+
+	ids := map[string]int{}
+	id := func(text string) int {
+		if value, ok := ids[text]; ok {
+			return value
+		}
+		ids[text] = len(ids)
+		return len(ids) - 1
+	}
+	differ := &myersDiffer{old: old, new: new, a: make([]int, len(old)), b: make([]int, len(new))}
+	for index, text := range old {
+		differ.a[index] = id(text)
+	}
+	shared := false
+	for index, text := range new {
+		_, found := ids[text]
+		shared = shared || found
+		differ.b[index] = id(text)
+	}
+	differ.edits = make([]diffEdit, 0, len(old)+len(new))
+	if !shared {
+		differ.change(0, len(old), 0, len(new))
+		return differ.edits
+	}
+
+	differ.offset = (len(old)+len(new)+1)/2 + 1
+	differ.forward = make([]int, 2*differ.offset+1)
+	differ.backward = make([]int, 2*differ.offset+1)
+	differ.compare(0, len(old), 0, len(new))
+
+	return differ.edits
+}
+
+// Method to append the edits turning old[aLow:aHigh] into new[bLow:bHigh]
+func (differ *myersDiffer) compare(aLow, aHigh, bLow, bHigh int) {
+	for aLow < aHigh && bLow < bHigh && differ.a[aLow] == differ.b[bLow] {
+		differ.edits = append(differ.edits, diffEdit{diffEqual, differ.old[aLow]})
+		aLow, bLow = aLow+1, bLow+1
+	}
+	suffix := 0
+	for aLow < aHigh-suffix && bLow < bHigh-suffix && differ.a[aHigh-1-suffix] == differ.b[bHigh-1-suffix] {
+		suffix++
+	}
+	aHigh, bHigh = aHigh-suffix, bHigh-suffix
+
+	if aLow == aHigh || bLow == bHigh {
+		differ.change(aLow, aHigh, bLow, bHigh)
+	} else {
+		x, y, u, v := differ.middleSnake(aLow, aHigh, bLow, bHigh)
+		differ.compare(aLow, x, bLow, y)
+		for ; x < u; x++ {
+			differ.edits = append(differ.edits, diffEdit{diffEqual, differ.old[x]})
+		}
+		differ.compare(u, aHigh, v, bHigh)
+	}
+
+	for index := aHigh; index < aHigh+suffix; index++ {
+		differ.edits = append(differ.edits, diffEdit{diffEqual, differ.old[index]})
+	}
+}
+
+// Method to append deletes of old[aLow:aHigh] followed by inserts of new[bLow:bHigh]
+func (differ *myersDiffer) change(aLow, aHigh, bLow, bHigh int) {
+	for _, text := range differ.old[aLow:aHigh] {
+		differ.edits = append(differ.edits, diffEdit{diffDelete, text})
+	}
+	for _, text := range differ.new[bLow:bHigh] {
+		differ.edits = append(differ.edits, diffEdit{diffInsert, text})
+	}
+}
+
+// Method to find the middle snake of the shortest edit path, searching from both ends until the paths overlap.
+// Returns the start and end of the snake. Both ranges must be non-empty and differ in their first and last lines
+func (differ *myersDiffer) middleSnake(aLow, aHigh, bLow, bHigh int) (int, int, int, int) {
+	n, m := aHigh-aLow, bHigh-bLow
+	delta := n - m
+	odd := delta%2 != 0
+	forward := func(diagonal int) *int { return &differ.forward[differ.offset+diagonal] }
+	backward := func(diagonal int) *int { return &differ.backward[differ.offset+diagonal] }
+	*forward(1), *backward(1) = 0, 0
+
+	for rounds := 0; rounds <= (n+m+1)/2; rounds++ {
+		for diagonal := -rounds; diagonal <= rounds; diagonal += 2 {
+			var x int
+			if diagonal == -rounds || (diagonal != rounds && *forward(diagonal - 1) < *forward(diagonal + 1)) {
+				x = *forward(diagonal + 1)
+			} else {
+				x = *forward(diagonal - 1) + 1
+			}
+			y := x - diagonal
+			startX, startY := x, y
+			for x < n && y < m && differ.a[aLow+x] == differ.b[bLow+y] {
+				x, y = x+1, y+1
+			}
+			*forward(diagonal) = x
+			// The backward path on the same diagonal was extended in the previous round
+			if odd && diagonal >= delta-rounds+1 && diagonal <= delta+rounds-1 && x+*backward(delta - diagonal) >= n {
+				return aLow + startX, bLow + startY, aLow + x, bLow + y
+			}
+		}
+
+		// Backward paths count lines from the end, diagonal k of the backward search is delta-k of the forward one
+		for diagonal := -rounds; diagonal <= rounds; diagonal += 2 {
+			var x int
+			if diagonal == -rounds || (diagonal != rounds && *backward(diagonal - 1) < *backward(diagonal + 1)) {
+				x = *backward(diagonal + 1)
+			} else {
+				x = *backward(diagonal - 1) + 1
+			}
+			y := x - diagonal
+			startX, startY := x, y
+			for x < n && y < m && differ.a[aHigh-1-x] == differ.b[bHigh-1-y] {
+				x, y = x+1, y+1
+			}
+			*backward(diagonal) = x
+			if !odd && delta-diagonal >= -rounds && delta-diagonal <= rounds && x+*forward(delta - diagonal) >= n {
+				return aHigh - x, bHigh - y, aHigh - startX, bHigh - startY
+			}
+		}
+	}
+
+	// The paths always overlap by the last round
+	panic("gochalk: middle snake not found")
+}
+
+// Method to group edits into unified diff hunks with the given number of unchanged lines around changes
+func diffHunks(edits []diffEdit, contextLines int) []string {
+	var hunks []string
+	oldLine, newLine := 0, 0
+	for index := 0; index < len(edits); {
+		if edits[index].op == diffEqual {
+			oldLine, newLine, index = oldLine+1, newLine+1, index+1
+			continue
+		}
+
+		start := max(index-contextLines, 0)
+		oldLine, newLine = oldLine-(index-start), newLine-(index-start)
+
+		end := index
+		for {
+			for end < len(edits) && edits[end].op != diffEqual {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].op == diffEqual {
+				next++
+			}
+			if next < len(edits) && next-end <= 2*contextLines {
+				end = next
+				continue
+			}
+			end = min(end+contextLines, len(edits))
+			break
+		}
+
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		for _, edit := range edits[start:end] {
+			marker := " "
+			switch edit.op {
+			case diffDelete:
+				marker = "-"
+				oldCount++
+			case diffInsert:
+				marker = "+"
+				newCount++
+			default:
+				oldCount, newCount = oldCount+1, newCount+1
+			}
+			body.WriteString(marker + edit.text)
+			if !strings.HasSuffix(edit.text, "\n") {
+				body.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		hunks = append(hunks, fmt.Sprintf("@@ -%s +%s @@\n%s", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount), body.String()))
+		oldLine, newLine, index = oldLine+oldCount, newLine+newCount, end
+	}
+
+	return hunks
+}
+
+// Method to format the line range of a hunk header. before is the number of lines before the hunk
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return strconv.Itoa(before + 1)
+	default:
+		return fmt.Sprintf("%d,%d", before+1, count)
+	}
+}
+
+// Method to classify the lines of unified diff text, following the line counts of hunk headers so removed lines
+// starting with "--" aren't mistaken for file headers
+func parseDiffLines(diff string) []diffLine {
+	rawLines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	lines := make([]diffLine, 0, len(rawLines))
+
+	oldLine, newLine, oldLeft, newLeft := 0, 0, 0, 0
+	for _, text := range rawLines {
+		inHunk := oldLeft > 0 || newLeft > 0
+		line := diffLine{kind: diffLineHeader, text: text}
+		switch {
+		case inHunk && strings.HasPrefix(text, "-"):
+			line = diffLine{kind: diffLineRemoved, text: text[1:], oldLine: oldLine}
+			oldLine, oldLeft = oldLine+1, oldLeft-1
+		case inHunk && strings.HasPrefix(text, "+"):
+			line = diffLine{kind: diffLineAdded, text: text[1:], newLine: newLine}
+			newLine, newLeft = newLine+1, newLeft-1
+		case inHunk && (strings.HasPrefix(text, " ") || text == ""):
+			line = diffLine{kind: diffLineContext, text: strings.TrimPrefix(text, " "), oldLine: oldLine, newLine: newLine}
+			oldLine, newLine, oldLeft, newLeft = oldLine+1, newLine+1, oldLeft-1, newLeft-1
+		case strings.HasPrefix(text, "\\"):
+			line.kind = diffLineNote
+		case strings.HasPrefix(text, "@@ "):
+			var oldStart, oldCount, newStart, newCount int
+			if parseHunkHeader(text, &oldStart, &oldCount, &newStart, &newCount) {
+				line.kind = diffLineHunk
+				oldLine, newLine, oldLeft, newLeft = max(oldStart, 1), max(newStart, 1), oldCount, newCount
+			}
+		}
+		line.segments = []diffSegment{{text: line.text}}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// Method to read the ranges of a "@@ -1,3 +1,4 @@" hunk header. Counts default to 1 when left out
+func parseHunkHeader(text string, oldStart, oldCount, newStart, newCount *int) bool {
+	fields := strings.Fields(text)
+	if len(fields) < 4 || fields[3] != "@@" || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return false
+	}
+
+	parse := func(field string, start, count *int) bool {
+		first, second, hasCount := strings.Cut(field[1:], ",")
+		var err error
+		if *start, err = strconv.Atoi(first); err != nil {
+			return false
+		}
+		*count = 1
+		if hasCount {
+			*count, err = strconv.Atoi(second)
+		}
+		return err == nil
+	}
+
+	return parse(fields[1], oldStart, oldCount) && parse(fields[2], newStart, newCount)
+}
+
+// Method to pair runs of removed lines with the added lines following them and mark the words that changed
+func highlightDiffWords(lines []diffLine) {
+	for index := 0; index < len(lines); {
+		if lines[index].kind != diffLineRemoved {
+			index++
+			continue
+		}
+
+		removedStart := index
+		for index < len(lines) && lines[index].kind == diffLineRemoved {
+			index++
+		}
+		addedStart := index
+		for index < len(lines) && lines[index].kind == diffLineAdded {
+			index++
+		}
+
+		for pair := 0; pair < min(addedStart-removedStart, index-addedStart); pair++ {
+			removed, added := &lines[removedStart+pair], &lines[addedStart+pair]
+			removed.segments, added.segments = diffWords(removed.text, added.text)
+		}
+	}
+}
+
+// Method to diff two lines word by word. Returns the segments of both lines, or the whole lines unchanged
+// when too little is shared
+func diffWords(old, new string) ([]diffSegment, []diffSegment) {
+	edits := myersDiff(splitWords(old), splitWords(new))
+
+	unchanged := 0
+	for _, edit := range edits {
+		if edit.op == diffEqual {
+			unchanged += len(edit.text)
+		}
+	}
+	if float64(2*unchanged) < diffWordSimilarity*float64(len(old)+len(new)) {
+		return []diffSegment{{text: old}}, []diffSegment{{text: new}}
+	}
+
+	var oldSegments, newSegments []diffSegment
+	appendSegment := func(segments []diffSegment, text string, changed bool) []diffSegment {
+		if last := len(segments) - 1; last >= 0 && segments[last].changed == changed {
+			segments[last].text += text
+			return segments
+		}
+		return append(segments, diffSegment{text, changed})
+	}
+	for _, edit := range edits {
+		switch edit.op {
+		case diffEqual:
+			oldSegments = appendSegment(oldSegments, edit.text, false)
+			newSegments = appendSegment(newSegments, edit.text, false)
+		case diffDelete:
+			oldSegments = appendSegment(oldSegments, edit.text, true)
+		case diffInsert:
+			newSegments = appendSegment(newSegments, edit.text, true)
+		}
+	}
+
+	return oldSegments, newSegments
+}
+
+// Method to split a line into words, runs of spaces and single punctuation characters
+func splitWords(line string) []string {
+	var words []string
+	kind := func(char rune) int {
+		switch {
+		case unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_':
+			return 1
+		case unicode.IsSpace(char):
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	start, previous := 0, 0
+	for index, char := range line {
+		current := kind(char)
+		if index > 0 && (current != previous || current == 3) {
+			words = append(words, line[start:index])
+			start = index
+		}
+		previous = current
+	}
+	if start < len(line) {
+		words = append(words, line[start:])
+	}
+
+	return words
+}
@@ -0,0 +1,218 @@
+package gochalk
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		old, new string
+		expected string
+	}{
+		{"a\nb\nc\n", "a\nB\nc\nd\n", "--- old\n+++ new\n@@ -1,3 +1,4 @@\n a\n-b\n+B\n c\n+d\n"},
+		{"a", "b", "--- old\n+++ new\n@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+b\n\\ No newline at end of file\n"},
+		{"", "x\n", "--- old\n+++ new\n@@ -0,0 +1 @@\n+x\n"},
+		{"x\n", "x\n", ""},
+	}
+
+	for _, test := range tests {
+		actualString := UnifiedDiff("old", "new", test.old, test.new, 3)
+		if strings.Compare(actualString, test.expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", test.expected, actualString)
+		}
+	}
+}
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	var old, new strings.Builder
+	for line := 1; line <= 10; line++ {
+		fmt.Fprintf(&old, "%d\n", line)
+		switch line {
+		case 2:
+			new.WriteString("two\n")
+		case 9:
+			new.WriteString("nine\n")
+		default:
+			fmt.Fprintf(&new, "%d\n", line)
+		}
+	}
+
+	actualString := UnifiedDiff("a", "b", old.String(), new.String(), 1)
+	expectedString := "--- a\n+++ b\n@@ -1,3 +1,3 @@\n 1\n-2\n+two\n 3\n@@ -8,3 +8,3 @@\n 8\n-9\n+nine\n 10\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = UnifiedDiff("a", "b", old.String(), new.String(), 3)
+	if strings.Count(actualString, "@@ -") != 1 || !strings.Contains(actualString, "@@ -1,10 +1,10 @@") {
+		t.Errorf("\nExpected: Hunks to be merged\nActual: %q", actualString)
+	}
+}
+
+func TestMyersDiff(t *testing.T) {
+	old := strings.Split("ABCABBA", "")
+	new := strings.Split("CBABAC", "")
+
+	edits := myersDiff(old, new)
+	var changes int
+	var rebuiltOld, rebuiltNew strings.Builder
+	for _, edit := range edits {
+		if edit.op != diffInsert {
+			rebuiltOld.WriteString(edit.text)
+		}
+		if edit.op != diffDelete {
+			rebuiltNew.WriteString(edit.text)
+		}
+		if edit.op != diffEqual {
+			changes++
+		}
+	}
+
+	if rebuiltOld.String() != "ABCABBA" || rebuiltNew.String() != "CBABAC" || changes != 5 {
+		t.Errorf("\nExpected: 5 changes turning ABCABBA into CBABAC\nActual: %d changes, %s -> %s", changes, rebuiltOld.String(), rebuiltNew.String())
+	}
+}
+
+// Method to check that edits turn old into new and return the number of changed lines
+func checkDiffEdits(t *testing.T, old, new []string, edits []diffEdit) int {
+	t.Helper()
+	var rebuiltOld, rebuiltNew []string
+	changes := 0
+	for _, edit := range edits {
+		if edit.op != diffInsert {
+			rebuiltOld = append(rebuiltOld, edit.text)
+		}
+		if edit.op != diffDelete {
+			rebuiltNew = append(rebuiltNew, edit.text)
+		}
+		if edit.op != diffEqual {
+			changes++
+		}
+	}
+	if !slices.Equal(rebuiltOld, old) || !slices.Equal(rebuiltNew, new) {
+		t.Fatalf("Edits don't turn %q into %q", old, new)
+	}
+
+	return changes
+}
+
+func TestMyersDiff_Shortest(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		old := strings.Split(randomDiffText(random, random.Intn(30)), "")
+		new := strings.Split(randomDiffText(random, random.Intn(30)), "")
+
+		// Length of the longest common subsequence gives the number of changes of a shortest diff
+		common := make([][]int, len(old)+1)
+		for index := range common {
+			common[index] = make([]int, len(new)+1)
+		}
+		for x := len(old) - 1; x >= 0; x-- {
+			for y := len(new) - 1; y >= 0; y-- {
+				if old[x] == new[y] {
+					common[x][y] = common[x+1][y+1] + 1
+				} else {
+					common[x][y] = max(common[x+1][y], common[x][y+1])
+				}
+			}
+		}
+
+		expected := len(old) + len(new) - 2*common[0][0]
+		if actual := checkDiffEdits(t, old, new, myersDiff(old, new)); actual != expected {
+			t.Errorf("\nExpected: %d changes turning %q into %q\nActual: %d", expected, old, new, actual)
+		}
+	}
+}
+
+func TestMyersDiff_Large(t *testing.T) {
+	old, new, mixed := make([]string, 10000), make([]string, 10000), make([]string, 10000)
+	for index := range old {
+		old[index] = fmt.Sprintf("old %d\n", index)
+		new[index] = fmt.Sprintf("new %d\n", index)
+		mixed[index] = old[index]
+		if index%3 == 0 {
+			mixed[index] = new[index]
+		}
+	}
+
+	if changes := checkDiffEdits(t, old, new, myersDiff(old, new)); changes != 20000 {
+		t.Errorf("\nExpected: 20000 changes\nActual: %d", changes)
+	}
+	if changes := checkDiffEdits(t, old, mixed, myersDiff(old, mixed)); changes != 6668 {
+		t.Errorf("\nExpected: 6668 changes\nActual: %d", changes)
+	}
+}
+
+func BenchmarkMyersDiff(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	old, new := make([]string, 10000), make([]string, 10000)
+	for index := range old {
+		old[index] = fmt.Sprintf("line %d\n", random.Intn(2000))
+		new[index] = fmt.Sprintf("line %d\n", random.Intn(2000))
+	}
+
+	b.ReportAllocs()
+	for round := 0; round < b.N; round++ {
+		myersDiff(old, new)
+	}
+}
+
+// Method to build random text from a small alphabet, so texts share many characters
+func randomDiffText(random *rand.Rand, length int) string {
+	var builder strings.Builder
+	for index := 0; index < length; index++ {
+		builder.WriteByte("abc"[random.Intn(3)])
+	}
+	return builder.String()
+}
+
+func TestDiff(t *testing.T) {
+	actualString := Diff("hello world\n", "hello there\n", nil)
+	expectedString := NewStyle(Bold).ToString("--- old") + "\n" +
+		NewStyle(Bold).ToString("+++ new") + "\n" +
+		NewStyle(FgCyan).ToString("@@ -1 +1 @@") + "\n" +
+		NewStyle(FgRed).ToString("-") + NewStyle(FgRed).ToString("hello ") + NewStyle(FgBlack, BgRed).ToString("world") + "\n" +
+		NewStyle(FgGreen).ToString("+") + NewStyle(FgGreen).ToString("hello ") + NewStyle(FgBlack, BgGreen).ToString("there")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Diff("abc\n", "xyz\n", nil)
+	if strings.Contains(actualString, escapedStyle(BgRed)) {
+		t.Errorf("\nExpected: Unrelated lines not to be highlighted by word\nActual: %q", actualString)
+	}
+}
+
+func TestColorizeDiff(t *testing.T) {
+	plain := NewStyle()
+	options := &DiffOptions{Header: plain, Hunk: NewStyle(FgCyan), Removed: NewStyle(FgRed), Added: plain, RemovedWord: plain, AddedWord: plain}
+
+	diff := "diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1,2 +1 @@ func main\n--- not a header\n-x\n+y\n"
+	actualString := ColorizeDiff(diff, options)
+	expectedString := "diff --git a/x b/x\n--- a/x\n+++ b/x\n" + NewStyle(FgCyan).ToString("@@ -1,2 +1 @@") + " func main\n" +
+		NewStyle(FgRed).ToString("-") + NewStyle(FgRed).ToString("-- not a header") + "\n" +
+		NewStyle(FgRed).ToString("-") + NewStyle(FgRed).ToString("x") + "\n+y"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDiff_SideBySide(t *testing.T) {
+	plain := NewStyle()
+	options := &DiffOptions{
+		SideBySide: true, Width: 30,
+		Header: plain, Hunk: plain, Removed: plain, Added: plain, RemovedWord: plain, AddedWord: plain, LineNumber: plain,
+	}
+
+	actualString := Diff("a\nb\n", "a\nc\tlonger line\n", options)
+	expectedString := "--- old\n+++ new\n@@ -1,2 +1,2 @@\n" +
+		"1  a          │ 1  a         \n" +
+		"2 -b          │ 2 +c    long…"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
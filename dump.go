@@ -0,0 +1,377 @@
+package gochalk
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Options for dumping Go values
+type DumpOptions struct {
+	// Indentation for each level. Defaults to two spaces
+	Indent string
+	// Levels of nested structs, maps, slices and arrays shown before they are cut off. Defaults to 10,
+	// a negative value shows all levels
+	MaxDepth int
+	// Show unexported struct fields
+	Unexported bool
+	// Show values as they are stored instead of using their String or Error method
+	DisableMethods bool
+	// Styles for type names, field names and map keys, strings, numbers, booleans, nil and notes such as <cycle>
+	TypeName  *Chalk
+	FieldName *Chalk
+	String    *Chalk
+	Number    *Chalk
+	Bool      *Chalk
+	Nil       *Chalk
+	Note      *Chalk
+	// ColorLevelNone gives a plain dump, e.g. for test failure messages. Detected for stdout by default
+	Level ColorLevel
+}
+
+// Identifies a pointer, map or slice on the path being dumped, to find cycles
+type dumpVisit struct {
+	pointer uintptr
+	typ     reflect.Type
+	length  int
+	isSlice bool
+}
+
+// State of a value being dumped
+type dumper struct {
+	options *DumpOptions
+	builder strings.Builder
+	visited map[dumpVisit]bool
+}
+
+// Method to format any Go value as readable, indented Go-like syntax with styled type names, field names and values.
+// Pointers are followed, cycles are marked with <cycle> and map keys are sorted. Passing nil options uses the
+// default styles and hides unexported fields
+//
+//	fmt.Println(gochalk.Dump(config, nil))
+//	t.Errorf("unexpected result:\n%s", gochalk.Dump(result, &gochalk.DumpOptions{Unexported: true}))
+func Dump(value any, options *DumpOptions) string {
+	dumper := &dumper{options: options.withDefaults(), visited: map[dumpVisit]bool{}}
+	dumper.value(reflect.ValueOf(value), 0)
+
+	return dumper.builder.String()
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *DumpOptions) withDefaults() *DumpOptions {
+	result := DumpOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Indent == "" {
+		result.Indent = "  "
+	}
+	if result.MaxDepth == 0 {
+		result.MaxDepth = 10
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	fillDefaultStyles([]defaultStyle{
+		{&result.TypeName, NewStyle(FgCyan)},
+		{&result.FieldName, NewStyle(Bold)},
+		{&result.String, NewStyle(FgGreen)},
+		{&result.Number, NewStyle(FgMagenta)},
+		{&result.Bool, NewStyle(FgYellow)},
+		{&result.Nil, NewStyle(FgBrightBlack)},
+		{&result.Note, NewStyle(FgBrightBlack, Italics)},
+	})
+
+	return &result
+}
+
+// Method to write text in the given style, or plain when colors are off
+func (dumper *dumper) write(chalk *Chalk, text string) {
+	dumper.builder.WriteString(paintStyle(dumper.options.Level, chalk, text))
+}
+
+// Method to write a new line indented for depth
+func (dumper *dumper) newLine(depth int) {
+	dumper.builder.WriteString("\n" + strings.Repeat(dumper.options.Indent, depth))
+}
+
+// Method to write a value nested depth levels deep
+func (dumper *dumper) value(value reflect.Value, depth int) {
+	options := dumper.options
+	if !value.IsValid() {
+		dumper.write(options.Nil, "nil")
+		return
+	}
+	// Methods are looked up on the value stored in an interface, so nil pointers inside interfaces are caught below
+	if value.Kind() == reflect.Interface {
+		dumper.value(value.Elem(), depth)
+		return
+	}
+	if dumper.method(value) {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		dumper.write(options.Bool, strconv.FormatBool(value.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dumper.write(options.Number, strconv.FormatInt(value.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		dumper.write(options.Number, strconv.FormatUint(value.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		dumper.write(options.Number, strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		dumper.write(options.Number, strconv.FormatComplex(value.Complex(), 'g', -1, value.Type().Bits()))
+	case reflect.String:
+		dumper.write(options.String, strconv.Quote(value.String()))
+	case reflect.Pointer:
+		dumper.pointer(value, depth)
+	case reflect.Struct:
+		dumper.structValue(value, depth)
+	case reflect.Map:
+		dumper.mapValue(value, depth)
+	case reflect.Slice, reflect.Array:
+		dumper.list(value, depth)
+	default:
+		// Channels, functions and unsafe pointers only show their type
+		if value.IsNil() {
+			dumper.nilValue(value.Type())
+		} else {
+			dumper.write(options.TypeName, value.Type().String())
+		}
+	}
+}
+
+// Method to write a value using its Error or String method. Returns false if the value has neither, is a nil pointer
+// or methods are disabled. Panics in the method are recovered and shown as a note, like fmt does
+func (dumper *dumper) method(value reflect.Value) bool {
+	if dumper.options.DisableMethods || !value.CanInterface() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return false
+	}
+
+	var call func() string
+	var name string
+	switch method := value.Interface().(type) {
+	case error:
+		call, name = method.Error, "Error"
+	case fmt.Stringer:
+		call, name = method.String, "String"
+	default:
+		return false
+	}
+
+	dumper.write(dumper.options.TypeName, value.Type().String())
+	dumper.builder.WriteString("(")
+	if text, panicked := callDumpMethod(call); panicked != nil {
+		dumper.write(dumper.options.Note, fmt.Sprintf("<PANIC=%s method: %v>", name, panicked))
+	} else {
+		dumper.write(dumper.options.String, strconv.Quote(text))
+	}
+	dumper.builder.WriteString(")")
+
+	return true
+}
+
+// Method to call an Error or String method, returning the value it panicked with if it did
+func callDumpMethod(call func() string) (text string, panicked any) {
+	defer func() {
+		panicked = recover()
+	}()
+
+	return call(), nil
+}
+
+// Method to write "(type)(nil)"
+func (dumper *dumper) nilValue(typ reflect.Type) {
+	dumper.builder.WriteString("(")
+	dumper.write(dumper.options.TypeName, typ.String())
+	dumper.builder.WriteString(")(")
+	dumper.write(dumper.options.Nil, "nil")
+	dumper.builder.WriteString(")")
+}
+
+// Method to write "type{note}" for values which are cut off
+func (dumper *dumper) cutOff(typeName string, note string) {
+	dumper.write(dumper.options.TypeName, typeName)
+	dumper.builder.WriteString("{")
+	dumper.write(dumper.options.Note, note)
+	dumper.builder.WriteString("}")
+}
+
+// Method to mark a pointer, map or slice as being dumped. Returns false if it is already on the path, which is a cycle
+func (dumper *dumper) enter(visit dumpVisit) bool {
+	if dumper.visited[visit] {
+		return false
+	}
+	dumper.visited[visit] = true
+	return true
+}
+
+// Method to write "&" followed by the value a pointer points to
+func (dumper *dumper) pointer(value reflect.Value, depth int) {
+	if value.IsNil() {
+		dumper.nilValue(value.Type())
+		return
+	}
+
+	visit := dumpVisit{pointer: value.Pointer(), typ: value.Type()}
+	if !dumper.enter(visit) {
+		dumper.builder.WriteString("&")
+		dumper.cutOff(value.Type().Elem().String(), "<cycle>")
+		return
+	}
+	defer delete(dumper.visited, visit)
+
+	dumper.builder.WriteString("&")
+	dumper.value(value.Elem(), depth)
+}
+
+// Method to write the fields of a struct, one per line
+func (dumper *dumper) structValue(value reflect.Value, depth int) {
+	typ := value.Type()
+	var fields []int
+	for index := 0; index < typ.NumField(); index++ {
+		if typ.Field(index).IsExported() || dumper.options.Unexported {
+			fields = append(fields, index)
+		}
+	}
+
+	typeName := typ.String()
+	if typ.Name() == "" {
+		typeName = "struct"
+	}
+	if len(fields) > 0 && dumper.tooDeep(depth) {
+		dumper.cutOff(typeName, "…")
+		return
+	}
+
+	dumper.write(dumper.options.TypeName, typeName)
+	dumper.builder.WriteString("{")
+	for _, index := range fields {
+		dumper.newLine(depth + 1)
+		dumper.write(dumper.options.FieldName, typ.Field(index).Name)
+		dumper.builder.WriteString(": ")
+		dumper.value(value.Field(index), depth+1)
+		dumper.builder.WriteString(",")
+	}
+	if len(fields) > 0 {
+		dumper.newLine(depth)
+	}
+	dumper.builder.WriteString("}")
+}
+
+// Method to write the entries of a map sorted by key, one per line
+func (dumper *dumper) mapValue(value reflect.Value, depth int) {
+	typeName := value.Type().String()
+	if value.IsNil() {
+		dumper.nilValue(value.Type())
+		return
+	}
+	if value.Len() > 0 && dumper.tooDeep(depth) {
+		dumper.cutOff(typeName, "…")
+		return
+	}
+
+	visit := dumpVisit{pointer: value.Pointer(), typ: value.Type()}
+	if !dumper.enter(visit) {
+		dumper.cutOff(typeName, "<cycle>")
+		return
+	}
+	defer delete(dumper.visited, visit)
+
+	keys := value.MapKeys()
+	sortMapKeys(keys)
+
+	dumper.write(dumper.options.TypeName, typeName)
+	dumper.builder.WriteString("{")
+	for _, key := range keys {
+		dumper.newLine(depth + 1)
+		dumper.value(key, depth+1)
+		dumper.builder.WriteString(": ")
+		dumper.value(value.MapIndex(key), depth+1)
+		dumper.builder.WriteString(",")
+	}
+	if len(keys) > 0 {
+		dumper.newLine(depth)
+	}
+	dumper.builder.WriteString("}")
+}
+
+// Method to write the elements of a slice or array, one per line. Byte slices are written as quoted strings
+func (dumper *dumper) list(value reflect.Value, depth int) {
+	typeName := value.Type().String()
+	if value.Kind() == reflect.Slice {
+		if value.IsNil() {
+			dumper.nilValue(value.Type())
+			return
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			dumper.write(dumper.options.TypeName, typeName)
+			dumper.builder.WriteString("(")
+			dumper.write(dumper.options.String, strconv.Quote(string(value.Bytes())))
+			dumper.builder.WriteString(")")
+			return
+		}
+
+		visit := dumpVisit{pointer: value.Pointer(), typ: value.Type(), length: value.Len(), isSlice: true}
+		if !dumper.enter(visit) {
+			dumper.cutOff(typeName, "<cycle>")
+			return
+		}
+		defer delete(dumper.visited, visit)
+	}
+	if value.Len() > 0 && dumper.tooDeep(depth) {
+		dumper.cutOff(typeName, "…")
+		return
+	}
+
+	dumper.write(dumper.options.TypeName, typeName)
+	dumper.builder.WriteString("{")
+	for index := 0; index < value.Len(); index++ {
+		dumper.newLine(depth + 1)
+		dumper.value(value.Index(index), depth+1)
+		dumper.builder.WriteString(",")
+	}
+	if value.Len() > 0 {
+		dumper.newLine(depth)
+	}
+	dumper.builder.WriteString("}")
+}
+
+// Method to check if the contents of a value at depth are past the depth limit
+func (dumper *dumper) tooDeep(depth int) bool {
+	return dumper.options.MaxDepth >= 0 && depth >= dumper.options.MaxDepth
+}
+
+// Method to sort map keys. Numbers and strings are compared by value, other keys by their plain dump
+func sortMapKeys(keys []reflect.Value) {
+	plain := &DumpOptions{Level: ColorLevelNone, DisableMethods: true, MaxDepth: 1}
+	sort.SliceStable(keys, func(first, second int) bool {
+		a, b := keys[first], keys[second]
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.String:
+				return a.String() < b.String()
+			}
+		}
+
+		return dumpPlain(a, plain) < dumpPlain(b, plain)
+	})
+}
+
+// Method to dump a reflected value without styles
+func dumpPlain(value reflect.Value, options *DumpOptions) string {
+	dumper := &dumper{options: options.withDefaults(), visited: map[dumpVisit]bool{}}
+	dumper.value(value, 0)
+
+	return dumper.builder.String()
+}
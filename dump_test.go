@@ -0,0 +1,190 @@
+package gochalk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type dumpConfig struct {
+	Name    string
+	Ports   []int
+	Tags    map[string]bool
+	Parent  *dumpConfig
+	Data    []byte
+	Extra   any
+	secret  string
+	Timeout float64
+}
+
+type dumpStringer struct{ name string }
+
+func (stringer *dumpStringer) String() string {
+	return stringer.name
+}
+
+type dumpError struct{ code int }
+
+func (err *dumpError) Error() string {
+	return fmt.Sprintf("code %d", err.code)
+}
+
+type dumpPanicker struct{}
+
+func (dumpPanicker) String() string {
+	panic("boom")
+}
+
+type dumpNode struct {
+	Value int
+	Next  *dumpNode
+}
+
+func TestDump(t *testing.T) {
+	config := dumpConfig{
+		Name:    "api",
+		Ports:   []int{80, 443},
+		Tags:    map[string]bool{"web": true, "beta": false},
+		Data:    []byte("hi"),
+		secret:  "hunter2",
+		Timeout: 1.5,
+	}
+
+	actualString := Dump(config, &DumpOptions{Level: ColorLevelNone})
+	expectedString := `gochalk.dumpConfig{
+  Name: "api",
+  Ports: []int{
+    80,
+    443,
+  },
+  Tags: map[string]bool{
+    "beta": false,
+    "web": true,
+  },
+  Parent: (*gochalk.dumpConfig)(nil),
+  Data: []uint8("hi"),
+  Extra: nil,
+  Timeout: 1.5,
+}`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestDump_Unexported(t *testing.T) {
+	actualString := Dump(struct {
+		Name   string
+		secret string
+	}{"api", "hunter2"}, &DumpOptions{Level: ColorLevelNone, Unexported: true, Indent: "\t"})
+	expectedString := "struct{\n\tName: \"api\",\n\tsecret: \"hunter2\",\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_Cycle(t *testing.T) {
+	node := &dumpNode{Value: 1}
+	node.Next = &dumpNode{Value: 2, Next: node}
+
+	actualString := Dump(node, &DumpOptions{Level: ColorLevelNone})
+	expectedString := `&gochalk.dumpNode{
+  Value: 1,
+  Next: &gochalk.dumpNode{
+    Value: 2,
+    Next: &gochalk.dumpNode{<cycle>},
+  },
+}`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+
+	values := map[string]any{"n": 1}
+	values["self"] = values
+	actualString = Dump(values, &DumpOptions{Level: ColorLevelNone})
+	expectedString = "map[string]interface {}{\n  \"n\": 1,\n  \"self\": map[string]interface {}{<cycle>},\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_SharedPointer(t *testing.T) {
+	shared := &dumpNode{Value: 7}
+	actualString := Dump([]*dumpNode{shared, shared}, &DumpOptions{Level: ColorLevelNone, Indent: " "})
+	expectedString := "[]*gochalk.dumpNode{\n &gochalk.dumpNode{\n  Value: 7,\n  Next: (*gochalk.dumpNode)(nil),\n },\n" +
+		" &gochalk.dumpNode{\n  Value: 7,\n  Next: (*gochalk.dumpNode)(nil),\n },\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_MaxDepth(t *testing.T) {
+	value := [][]int{{1, 2}, {}}
+	actualString := Dump(value, &DumpOptions{Level: ColorLevelNone, MaxDepth: 1})
+	expectedString := "[][]int{\n  []int{…},\n  []int{},\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_SortedKeys(t *testing.T) {
+	actualString := Dump(map[int]string{10: "ten", 2: "two", -1: "minus one"}, &DumpOptions{Level: ColorLevelNone})
+	expectedString := "map[int]string{\n  -1: \"minus one\",\n  2: \"two\",\n  10: \"ten\",\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_Methods(t *testing.T) {
+	err := errors.New("not found")
+	actualString := Dump(err, &DumpOptions{Level: ColorLevelNone})
+	expectedString := `*errors.errorString("not found")`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Dump(err, &DumpOptions{Level: ColorLevelNone, DisableMethods: true})
+	expectedString = "&errors.errorString{}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_NilMethods(t *testing.T) {
+	options := &DumpOptions{Level: ColorLevelNone}
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{struct{ X fmt.Stringer }{(*dumpStringer)(nil)}, "struct{\n  X: (*gochalk.dumpStringer)(nil),\n}"},
+		{[]error{(*dumpError)(nil), &dumpError{code: 2}}, "[]error{\n  (*gochalk.dumpError)(nil),\n  *gochalk.dumpError(\"code 2\"),\n}"},
+		{[]any{(*dumpStringer)(nil)}, "[]interface {}{\n  (*gochalk.dumpStringer)(nil),\n}"},
+		{struct{ X fmt.Stringer }{}, "struct{\n  X: nil,\n}"},
+	}
+	for _, test := range tests {
+		actualString := Dump(test.value, options)
+		if strings.Compare(actualString, test.expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", test.expected, actualString)
+		}
+	}
+}
+
+func TestDump_PanickingMethod(t *testing.T) {
+	actualString := Dump([]fmt.Stringer{dumpPanicker{}}, &DumpOptions{Level: ColorLevelNone})
+	expectedString := "[]fmt.Stringer{\n  gochalk.dumpPanicker(<PANIC=String method: boom>),\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDump_Styles(t *testing.T) {
+	actualString := Dump([]any{"a", 1, true, nil}, &DumpOptions{Level: ColorLevel16, Indent: " "})
+	expectedString := NewStyle(FgCyan).ToString("[]interface {}") + "{\n " +
+		NewStyle(FgGreen).ToString(`"a"`) + ",\n " +
+		NewStyle(FgMagenta).ToString("1") + ",\n " +
+		NewStyle(FgYellow).ToString("true") + ",\n " +
+		NewStyle(FgBrightBlack).ToString("nil") + ",\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
@@ -0,0 +1,229 @@
+package gochalk
+
+import (
+	"os"
+	"strings"
+)
+
+// Errors implementing ErrorHint show their hint below their message in FormatError
+type ErrorHint interface {
+	error
+	Hint() string
+}
+
+// Errors implementing ErrorFix show their suggested fix below their message in FormatError
+type ErrorFix interface {
+	error
+	SuggestedFix() string
+}
+
+// Options for formatting errors
+type ErrorOptions struct {
+	// Style of the top message
+	Message *Chalk
+	// Style of wrapped causes
+	Cause *Chalk
+	// Styles of hints and suggested fixes
+	Hint *Chalk
+	Fix  *Chalk
+	// Style of the tree lines
+	Tree *Chalk
+	// Color level of the output, detected for stdout by default. Use ColorLevelNone for log files
+	Level ColorLevel
+}
+
+// Error annotated by WithHint or WithFix
+type annotatedError struct {
+	err  error
+	hint string
+	fix  string
+}
+
+// Message and notes of an error in the chain, with the errors it wraps
+type errorNode struct {
+	message  string
+	notes    []errorNote
+	children []*errorNode
+}
+
+// Hint or suggested fix of an error
+type errorNote struct {
+	text string
+	fix  bool
+}
+
+// Method to wrap err with a hint shown by FormatError. The message of err is unchanged and errors.Is and errors.As
+// still find it
+//
+//	return gochalk.WithHint(err, "run `app init` to create a config file")
+func WithHint(err error, hint string) error {
+	return &annotatedError{err: err, hint: hint}
+}
+
+// Method to wrap err with a suggested fix shown by FormatError. The message of err is unchanged and errors.Is and
+// errors.As still find it
+//
+//	return gochalk.WithFix(err, "chmod 600 ~/.app/credentials")
+func WithFix(err error, fix string) error {
+	return &annotatedError{err: err, fix: fix}
+}
+
+// Method to get the message of the wrapped error
+func (err *annotatedError) Error() string {
+	return err.err.Error()
+}
+
+// Method to get the wrapped error
+func (err *annotatedError) Unwrap() error {
+	return err.err
+}
+
+// Method to get the hint of the error
+func (err *annotatedError) Hint() string {
+	return err.hint
+}
+
+// Method to get the suggested fix of the error
+func (err *annotatedError) SuggestedFix() string {
+	return err.fix
+}
+
+// Method to format an error and the errors it wraps, found with Unwrap() error and Unwrap() []error, as a tree.
+// Each cause shows only the part of the message it adds, so "load config: open app.yaml: permission denied" becomes
+// three lines. Hints and suggested fixes of errors implementing ErrorHint or ErrorFix are shown below their message.
+// Passing nil options uses the default styles
+//
+//	fmt.Fprintln(os.Stderr, gochalk.FormatError(err, nil))
+//
+// Output:
+//
+//	load config
+//	└─ open app.yaml
+//	   │ hint: run `app init` to create a config file
+//	   └─ permission denied
+func FormatError(err error, options *ErrorOptions) string {
+	if err == nil {
+		return ""
+	}
+	options = options.withDefaults()
+
+	var lines []string
+	for _, node := range buildErrorNodes(err) {
+		lines = options.render(lines, node, "", "", options.Message)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Method to print an error tree to stderr, using the color level detected for stderr
+//
+//	if err := run(); err != nil {
+//		gochalk.PrintError(err)
+//		os.Exit(1)
+//	}
+func PrintError(err error) {
+	options := &ErrorOptions{Level: detectColorLevel(os.Getenv, isTerminal(os.Stderr))}
+	os.Stderr.WriteString(FormatError(err, options) + "\n")
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *ErrorOptions) withDefaults() *ErrorOptions {
+	result := ErrorOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	fillDefaultStyles([]defaultStyle{
+		{&result.Message, NewStyle(FgRed, Bold)},
+		{&result.Cause, NewStyle(Dim)},
+		{&result.Hint, NewStyle(FgCyan)},
+		{&result.Fix, NewStyle(FgGreen)},
+		{&result.Tree, NewStyle(FgBrightBlack)},
+	})
+
+	return &result
+}
+
+// Method to append the lines of node and its children. The first line starts with branch, the following lines with
+// prefix
+func (options *ErrorOptions) render(lines []string, node *errorNode, branch string, prefix string, style *Chalk) []string {
+	guide := ""
+	if len(node.children) > 0 {
+		guide = "│ "
+	}
+
+	for index, line := range strings.Split(node.message, "\n") {
+		if index == 0 {
+			lines = append(lines, paintStyle(options.Level, options.Tree, branch)+paintStyle(options.Level, style, line))
+		} else {
+			lines = append(lines, paintStyle(options.Level, options.Tree, prefix+guide)+paintStyle(options.Level, style, line))
+		}
+	}
+	for _, note := range node.notes {
+		label, chalk := "hint: ", options.Hint
+		if note.fix {
+			label, chalk = "fix: ", options.Fix
+		}
+		lines = append(lines, paintStyle(options.Level, options.Tree, prefix+guide)+paintStyle(options.Level, chalk, label+note.text))
+	}
+
+	for index, child := range node.children {
+		if index == len(node.children)-1 {
+			lines = options.render(lines, child, prefix+"└─ ", prefix+"   ", options.Cause)
+		} else {
+			lines = options.render(lines, child, prefix+"├─ ", prefix+"│  ", options.Cause)
+		}
+	}
+
+	return lines
+}
+
+// Method to build the tree of an error. Errors that add nothing to the message of the errors they wrap, such as
+// errors.Join or WithHint, are left out. Their children take their place and their notes move to the first child
+func buildErrorNodes(err error) []*errorNode {
+	var wrapped []error
+	switch unwrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if child := unwrapper.Unwrap(); child != nil {
+			wrapped = []error{child}
+		}
+	case interface{ Unwrap() []error }:
+		for _, child := range unwrapper.Unwrap() {
+			if child != nil {
+				wrapped = append(wrapped, child)
+			}
+		}
+	}
+
+	node := &errorNode{message: err.Error()}
+	var messages []string
+	for _, child := range wrapped {
+		messages = append(messages, child.Error())
+		node.children = append(node.children, buildErrorNodes(child)...)
+	}
+	switch {
+	case len(messages) == 0:
+	case node.message == strings.Join(messages, "\n"):
+		node.message = ""
+	case len(messages) == 1:
+		node.message = strings.TrimSuffix(node.message, ": "+messages[0])
+	}
+
+	if hint, ok := err.(ErrorHint); ok && hint.Hint() != "" {
+		node.notes = append(node.notes, errorNote{text: hint.Hint()})
+	}
+	if fix, ok := err.(ErrorFix); ok && fix.SuggestedFix() != "" {
+		node.notes = append(node.notes, errorNote{text: fix.SuggestedFix(), fix: true})
+	}
+
+	if node.message == "" && len(node.children) > 0 {
+		first := node.children[0]
+		first.notes = append(node.notes, first.notes...)
+		return node.children
+	}
+
+	return []*errorNode{node}
+}
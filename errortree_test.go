@@ -0,0 +1,71 @@
+package gochalk
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+type fixError struct{}
+
+func (fixError) Error() string        { return "token expired" }
+func (fixError) SuggestedFix() string { return "run `app login`" }
+
+func TestFormatError(t *testing.T) {
+	open := &fs.PathError{Op: "open", Path: "app.yaml", Err: fs.ErrPermission}
+	err := fmt.Errorf("load config: %w", WithHint(open, "check the file owner"))
+
+	actualString := FormatError(err, &ErrorOptions{Level: ColorLevelNone})
+	expectedString := "load config\n" +
+		"└─ open app.yaml\n" +
+		"   │ hint: check the file owner\n" +
+		"   └─ permission denied"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("\nExpected: errors.Is to find the wrapped error")
+	}
+}
+
+func TestFormatError_Join(t *testing.T) {
+	err := fmt.Errorf("validate: %w", errors.Join(
+		fmt.Errorf("name: %w", errors.New("required")),
+		fixError{},
+	))
+
+	actualString := FormatError(err, &ErrorOptions{Level: ColorLevelNone})
+	expectedString := "validate\n" +
+		"├─ name\n" +
+		"│  └─ required\n" +
+		"└─ token expired\n" +
+		"   fix: run `app login`"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+
+	actualString = FormatError(errors.Join(errors.New("first"), errors.New("second")), &ErrorOptions{Level: ColorLevelNone})
+	expectedString = "first\nsecond"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestFormatError_Styles(t *testing.T) {
+	err := WithFix(fmt.Errorf("deploy: %w", errors.New("timeout")), "retry with --wait")
+
+	actualString := FormatError(err, &ErrorOptions{Level: ColorLevel16})
+	tree := NewStyle(FgBrightBlack)
+	expectedString := NewStyle(FgRed, Bold).ToString("deploy") + "\n" +
+		tree.ToString("│ ") + NewStyle(FgGreen).ToString("fix: retry with --wait") + "\n" +
+		tree.ToString("└─ ") + NewStyle(Dim).ToString("timeout")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	if actualString := FormatError(nil, nil); actualString != "" {
+		t.Errorf("\nExpected: %q\nActual: %q", "", actualString)
+	}
+}
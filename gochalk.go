@@ -3,6 +3,7 @@ package gochalk
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -65,11 +66,86 @@ const (
 	Dim
 	Italics
 	Underlined
+	Blink
 )
 
+// Text display style - less commonly supported
+const (
+	Reset         Style = 0
+	Inverse       Style = 7
+	Hidden        Style = 8
+	Strikethrough Style = 9
+)
+
+// 256 and 24-bit colors are stored in Style values above the range of SGR codes.
+// The upper bits hold the kind of color and the lower 24 bits the color index or RGB value
+const (
+	styleKindShift       = 24
+	styleValueMask Style = 1<<styleKindShift - 1
+)
+
+// Kinds of extended color styles. Ordered so that sorted styles put foreground before background
+const (
+	styleKindFg256 = iota + 1
+	styleKindFgRGB
+	styleKindBg256
+	styleKindBgRGB
+)
+
+// Method to get a foreground style from the 256 color range
+func Fg256(index uint8) Style {
+	return styleKindFg256<<styleKindShift | Style(index)
+}
+
+// Method to get a background style from the 256 color range
+func Bg256(index uint8) Style {
+	return styleKindBg256<<styleKindShift | Style(index)
+}
+
+// Method to get a 24-bit foreground style. Requires a terminal supporting true color
+func FgRGB(r, g, b uint8) Style {
+	return styleKindFgRGB<<styleKindShift | Style(r)<<16 | Style(g)<<8 | Style(b)
+}
+
+// Method to get a 24-bit background style. Requires a terminal supporting true color
+func BgRGB(r, g, b uint8) Style {
+	return styleKindBgRGB<<styleKindShift | Style(r)<<16 | Style(g)<<8 | Style(b)
+}
+
+// Method to get the SGR parameters for a style, e.g. "31" for FgRed or "38;5;208" for Fg256(208).
+// Basic red and green styles are replaced in colorblind safe mode
+func styleCode(style Style) string {
+	style = colorblindSafeStyle(style)
+	value := style & styleValueMask
+	switch style >> styleKindShift {
+	case styleKindFg256:
+		return fmt.Sprintf("38;5;%d", value)
+	case styleKindBg256:
+		return fmt.Sprintf("48;5;%d", value)
+	case styleKindFgRGB:
+		return fmt.Sprintf("38;2;%d;%d;%d", value>>16, value>>8&0xff, value&0xff)
+	case styleKindBgRGB:
+		return fmt.Sprintf("48;2;%d;%d;%d", value>>16, value>>8&0xff, value&0xff)
+	default:
+		return strconv.Itoa(int(style))
+	}
+}
+
+// Method to check if style sets the foreground color
+func isForeground(style Style) bool {
+	kind := style >> styleKindShift
+	return (style >= 30 && style < 38) || (style >= 90 && style < 98) || kind == styleKindFg256 || kind == styleKindFgRGB
+}
+
+// Method to check if style sets the background color
+func isBackground(style Style) bool {
+	kind := style >> styleKindShift
+	return (style >= 40 && style < 48) || (style >= 100 && style < 108) || kind == styleKindBg256 || kind == styleKindBgRGB
+}
+
 // Method to return styles in escaped string format
 func escapedStyle(style Style) string {
-	return fmt.Sprintf("%s[%dm", escape, style)
+	return fmt.Sprintf("%s[%sm", escape, styleCode(style))
 }
 
 // Method to return multiple styles in escaped string format. Use when a single style needs to be applied
@@ -122,11 +198,7 @@ func StyledString(val string, styles ...Style) string {
 
 	slices.Sort(stylesCopy)
 
-	finalStyle := ""
-	for _, str := range stylesCopy {
-		finalStyle += fmt.Sprint(str) + ";"
-	}
-	finalStyle = finalStyle[0 : len(finalStyle)-1]
+	finalStyle := convertIntSliceToString(stylesCopy)
 
 	stringWithNoNewLine := removeNewLine(val)
 	styledString := getMultipleStyledString(finalStyle, stringWithNoNewLine)
@@ -139,6 +211,9 @@ func StyledString(val string, styles ...Style) string {
 
 type Chalk struct {
 	styles []Style
+	// Set when strings should be sanitized before styling. See Chalk.Safe
+	safe         bool
+	sanitizeMode SanitizeMode
 }
 
 // Creates a new Chalk object with the provided styles. This object can then be reused to apply required styles to strings
@@ -185,7 +260,8 @@ func (chalk *Chalk) Add(styles ...Style) *Chalk {
 
 	slices.Sort(stylesCopy)
 
-	newChalk := Chalk{styles: stylesCopy}
+	newChalk := *chalk
+	newChalk.styles = stylesCopy
 	return &newChalk
 }
 
@@ -202,15 +278,15 @@ func (chalk *Chalk) Remove(styles ...Style) *Chalk {
 	stylesCopy := slices.Clone(chalk.styles)
 	stylesFiltered := filterSlice(stylesCopy, styles)
 
-	newChalk := &Chalk{styles: stylesFiltered}
+	newChalk := *chalk
+	newChalk.styles = stylesFiltered
 
-	return newChalk
+	return &newChalk
 }
 
-// Method to remove all styles applied to Chalk
+// Method to remove all styles applied to Chalk. Safe mode is kept
 func (chalk *Chalk) RemoveAll() *Chalk {
-	chalk = nil
-	return &Chalk{}
+	return &Chalk{safe: chalk.safe, sanitizeMode: chalk.sanitizeMode}
 }
 
 // Method to print string provided wrapped in styles present in Chalk
@@ -223,6 +299,9 @@ func (chalk *Chalk) ToString(value ...string) string {
 	if len(value) == 0 {
 		return ""
 	}
+	if chalk.safe {
+		value = sanitizeAll(chalk.sanitizeMode, value...)
+	}
 	combinedValue := combineStrings(value...)
 
 	var combinedStyleString string
@@ -240,10 +319,40 @@ func (chalk *Chalk) ToString(value ...string) string {
 // Utility Methods
 // -------------------------
 
+// Style field of an options struct with the style it defaults to
+type defaultStyle struct {
+	field **Chalk
+	value *Chalk
+}
+
+// Method to set the style fields of options which weren't set to their defaults
+func fillDefaultStyles(styles []defaultStyle) {
+	for _, style := range styles {
+		if *style.field == nil {
+			*style.field = style.value
+		}
+	}
+}
+
+// Method to style text written at a color level. Text is returned unchanged when it is empty or the level is
+// ColorLevelNone
+func paintStyle(level ColorLevel, chalk *Chalk, text string) string {
+	if level == ColorLevelNone || text == "" {
+		return text
+	}
+
+	return overlayStyle(chalk, text)
+}
+
 // Method to convert int slice to a single string.
 // Used to create a single string with ';' delimeter for using in styles
 func convertIntSliceToString(arr []Style) string {
-	return strings.Trim(strings.Replace(fmt.Sprint(arr), " ", ";", -1), "[]")
+	codes := make([]string, 0, len(arr))
+	for _, style := range arr {
+		codes = append(codes, styleCode(style))
+	}
+
+	return strings.Join(codes, ";")
 }
 
 // Method to join all variadic string params
@@ -302,7 +411,7 @@ func getLastForeground(styles ...Style) Style {
 	for index := range styles {
 		reverseIndex := len(styles) - index - 1
 		style := styles[reverseIndex]
-		if (style >= 30 && style < 38) || (style >= 90 && style < 98) {
+		if isForeground(style) {
 			return style
 		}
 	}
@@ -315,7 +424,7 @@ func getLastBackground(styles ...Style) Style {
 	for index := range styles {
 		reverseIndex := len(styles) - index - 1
 		style := styles[reverseIndex]
-		if (style >= 40 && style < 48) || (style >= 100 && style < 108) {
+		if isBackground(style) {
 			return style
 		}
 	}
@@ -329,7 +438,7 @@ func addorReplaceForeground(color Style, styles ...Style) []Style {
 	var stylesCopy []Style
 	for index := range styles {
 		style := styles[index]
-		if (style >= 30 && style < 38) || (style >= 90 && style < 98) {
+		if isForeground(style) {
 			if !replaced {
 				// styles[index] = color
 				stylesCopy = append(stylesCopy, color)
@@ -353,7 +462,7 @@ func addorReplaceBackground(color Style, styles ...Style) []Style {
 	var stylesCopy []Style
 	for index := range styles {
 		style := styles[index]
-		if (style >= 40 && style < 48) || (style >= 100 && style < 108) {
+		if isBackground(style) {
 			if !replaced {
 				// styles[index] = color
 				stylesCopy = append(stylesCopy, color)
@@ -540,3 +540,23 @@ func TestBasicStyle(t *testing.T) {
 		}
 	}
 }
+
+func TestExtendedColors(t *testing.T) {
+	actualString := StyledString(testString, Fg256(208), BgRGB(1, 2, 3), Bold)
+	expectedString := fmt.Sprintf("%s[%sm%s%s", escape, "1;38;5;208;48;2;1;2;3", testString, resetStyle)
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestExtendedColors_Replace(t *testing.T) {
+	chalk := NewStyle(FgRed, BgBlue).Add(FgRGB(255, 0, 128), Bg256(17))
+
+	actualString := chalk.ToString(testString)
+	expectedString := fmt.Sprintf("%s[%sm%s%s", escape, "38;2;255;0;128;48;5;17", testString, resetStyle)
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
@@ -0,0 +1,164 @@
+package gochalk
+
+import (
+	"math"
+	"strings"
+)
+
+// Color space used to blend colors of a gradient
+type Interpolation int
+
+const (
+	// Blend red, green and blue channels. Middle of the gradient can look muddy between complementary colors
+	InterpolateRGB Interpolation = iota
+	// Blend around the hue wheel taking the shorter way, keeping colors saturated
+	InterpolateHSL
+	// Blend in the perceptual OKLab space, giving evenly spaced steps in brightness
+	InterpolateOKLab
+)
+
+// Options for gradient and rainbow text
+type GradientOptions struct {
+	Interpolation Interpolation
+	// Colors are downsampled to the closest 256 or basic color below ColorLevelTrueColor.
+	// Defaults to the level detected for stdout. ColorLevelNone returns the text unchanged
+	Level ColorLevel
+	// Color the background instead of the foreground
+	Background bool
+}
+
+// Method to color each grapheme of value along a gradient between two or more colors.
+// Each line runs through the whole gradient from left to right, so lines of a multi-line banner share the same
+// color columns. Escape sequences in value are kept. Passing nil options uses RGB interpolation on the foreground
+//
+//	gochalk.Gradient("gochalk", []gochalk.Color{{255, 0, 128}, {0, 128, 255}}, nil)
+func Gradient(value string, colors []Color, options *GradientOptions) string {
+	if len(colors) == 0 {
+		return value
+	}
+	options = options.withDefaults()
+
+	return colorColumns(value, options, func(position float64) Color {
+		return gradientColor(colors, position, options.Interpolation)
+	})
+}
+
+// Method to color each grapheme of value with a hue going once around the color wheel.
+// Interpolation is ignored, colors are fully saturated HSL hues
+//
+//	gochalk.Rainbow("Build succeeded", nil)
+func Rainbow(value string, options *GradientOptions) string {
+	options = options.withDefaults()
+
+	return colorColumns(value, options, func(position float64) Color {
+		// Stop before 360 degrees so the last column isn't red again
+		return hslToRGB(position*300, 1, 0.5)
+	})
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *GradientOptions) withDefaults() *GradientOptions {
+	result := GradientOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	return &result
+}
+
+// Method to color each grapheme using the color for its column, given as a position between 0 and 1
+// across the widest line. Codes are only written when the color changes or after other escape sequences
+func colorColumns(value string, options *GradientOptions, colorAt func(position float64) Color) string {
+	if options.Level <= ColorLevelNone {
+		return value
+	}
+
+	width := 0
+	for _, line := range strings.Split(value, "\n") {
+		width = max(width, StringWidth(line))
+	}
+
+	var builder strings.Builder
+	column, current, colored := 0, "", false
+	for _, token := range Tokenize(value) {
+		if token.Kind != TokenText {
+			if token.Raw == "\n" {
+				column = 0
+			}
+			builder.WriteString(token.Raw)
+			current = ""
+			continue
+		}
+
+		for _, cluster := range graphemes(token.Raw) {
+			position := 0.0
+			if width > 1 {
+				position = float64(column) / float64(width-1)
+			}
+			code := escapedStyle(colorStyle(colorAt(position), options.Level, options.Background))
+			if code != current {
+				builder.WriteString(code)
+				current, colored = code, true
+			}
+			builder.WriteString(cluster)
+			column += graphemeWidth(cluster)
+		}
+	}
+
+	if colored {
+		builder.WriteString(resetStyle)
+	}
+
+	return builder.String()
+}
+
+// Method to get the color at position between 0 and 1 of a gradient through colors
+func gradientColor(colors []Color, position float64, interpolation Interpolation) Color {
+	if len(colors) == 1 {
+		return colors[0]
+	}
+
+	scaled := math.Min(math.Max(position, 0), 1) * float64(len(colors)-1)
+	segment := min(int(scaled), len(colors)-2)
+
+	return interpolateColor(colors[segment], colors[segment+1], scaled-float64(segment), interpolation)
+}
+
+// Method to blend from first to second color, where amount 0 gives first and 1 gives second
+func interpolateColor(first, second Color, amount float64, interpolation Interpolation) Color {
+	lerp := func(from, to float64) float64 {
+		return from + (to-from)*amount
+	}
+
+	switch interpolation {
+	case InterpolateHSL:
+		firstHue, firstSaturation, firstLightness := rgbToHSL(first)
+		secondHue, secondSaturation, secondLightness := rgbToHSL(second)
+		// Gray has no hue, so keep the hue of the other color instead of sweeping from red
+		if firstSaturation == 0 {
+			firstHue = secondHue
+		}
+		if secondSaturation == 0 {
+			secondHue = firstHue
+		}
+		if secondHue-firstHue > 180 {
+			secondHue -= 360
+		} else if firstHue-secondHue > 180 {
+			secondHue += 360
+		}
+		return hslToRGB(lerp(firstHue, secondHue), lerp(firstSaturation, secondSaturation), lerp(firstLightness, secondLightness))
+	case InterpolateOKLab:
+		firstL, firstA, firstB := rgbToOKLab(first)
+		secondL, secondA, secondB := rgbToOKLab(second)
+		return okLabToRGB(lerp(firstL, secondL), lerp(firstA, secondA), lerp(firstB, secondB))
+	default:
+		return Color{
+			uint8(math.Round(lerp(float64(first.R), float64(second.R)))),
+			uint8(math.Round(lerp(float64(first.G), float64(second.G)))),
+			uint8(math.Round(lerp(float64(first.B), float64(second.B)))),
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	gradientRed  = Color{255, 0, 0}
+	gradientBlue = Color{0, 0, 255}
+)
+
+func TestGradient(t *testing.T) {
+	options := &GradientOptions{Level: ColorLevelTrueColor}
+
+	actualString := Gradient("abc", []Color{gradientRed, gradientBlue}, options)
+	expectedString := escapedStyle(FgRGB(255, 0, 0)) + "a" + escapedStyle(FgRGB(128, 0, 128)) + "b" +
+		escapedStyle(FgRGB(0, 0, 255)) + "c" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestGradient_Interpolation(t *testing.T) {
+	options := &GradientOptions{Interpolation: InterpolateHSL, Level: ColorLevelTrueColor}
+
+	actualString := Gradient("abc", []Color{gradientRed, gradientBlue}, options)
+	expectedString := escapedStyle(FgRGB(255, 0, 0)) + "a" + escapedStyle(FgRGB(255, 0, 255)) + "b" +
+		escapedStyle(FgRGB(0, 0, 255)) + "c" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	for _, color := range []Color{gradientRed, gradientBlue, {18, 52, 86}, {255, 255, 255}} {
+		actual := interpolateColor(color, Color{}, 0, InterpolateOKLab)
+		if actual != color {
+			t.Errorf("\nExpected: %v\nActual: %v", color, actual)
+		}
+	}
+
+	actual := interpolateColor(Color{}, Color{255, 255, 255}, 0.5, InterpolateOKLab)
+	expected := Color{99, 99, 99}
+	if actual != expected {
+		t.Errorf("\nExpected: %v\nActual: %v", expected, actual)
+	}
+}
+
+func TestGradient_Stops(t *testing.T) {
+	options := &GradientOptions{Level: ColorLevelTrueColor}
+
+	actualString := Gradient("abcde", []Color{gradientRed, {0, 255, 0}, gradientBlue}, options)
+	expectedString := escapedStyle(FgRGB(255, 0, 0)) + "a" + escapedStyle(FgRGB(128, 128, 0)) + "b" +
+		escapedStyle(FgRGB(0, 255, 0)) + "c" + escapedStyle(FgRGB(0, 128, 128)) + "d" +
+		escapedStyle(FgRGB(0, 0, 255)) + "e" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestGradient_Downsample(t *testing.T) {
+	colors := []Color{gradientRed, gradientBlue}
+
+	actualString := Gradient("ab", colors, &GradientOptions{Level: ColorLevel256})
+	expectedString := escapedStyle(Fg256(196)) + "a" + escapedStyle(Fg256(21)) + "b" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Gradient("ab", colors, &GradientOptions{Level: ColorLevel16, Background: true})
+	expectedString = escapedStyle(BgBrightRed) + "a" + escapedStyle(BgBlue) + "b" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Gradient("ab", colors, &GradientOptions{Level: ColorLevelNone})
+	expectedString = "ab"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	if index := nearestColor256(Color{0x80, 0x80, 0x80}); index != 244 {
+		t.Errorf("\nExpected: 244\nActual: %d", index)
+	}
+}
+
+func TestGradient_Lines(t *testing.T) {
+	options := &GradientOptions{Level: ColorLevelTrueColor}
+
+	actualString := Gradient(TextBold("ab")+"\nab", []Color{gradientRed, gradientBlue}, options)
+	expectedString := escapedStyle(Bold) + escapedStyle(FgRGB(255, 0, 0)) + "a" + escapedStyle(FgRGB(0, 0, 255)) + "b" +
+		resetStyle + "\n" + escapedStyle(FgRGB(255, 0, 0)) + "a" + escapedStyle(FgRGB(0, 0, 255)) + "b" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Gradient("a b", []Color{gradientRed}, options)
+	expectedString = escapedStyle(FgRGB(255, 0, 0)) + "a b" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestRainbow(t *testing.T) {
+	actualString := Rainbow("abc", &GradientOptions{Level: ColorLevelTrueColor})
+	expectedString := escapedStyle(FgRGB(255, 0, 0)) + "a" + escapedStyle(FgRGB(0, 255, 128)) + "b" +
+		escapedStyle(FgRGB(255, 0, 255)) + "c" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		terminal bool
+		expected ColorLevel
+	}{
+		{map[string]string{"TERM": "xterm-256color"}, true, ColorLevel256},
+		{map[string]string{"TERM": "xterm", "COLORTERM": "truecolor"}, true, ColorLevelTrueColor},
+		{map[string]string{"TERM": "xterm"}, true, ColorLevel16},
+		{map[string]string{"TERM": "dumb"}, true, ColorLevelNone},
+		{map[string]string{"TERM": "xterm-256color"}, false, ColorLevelNone},
+		{map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, true, ColorLevelNone},
+		{map[string]string{"FORCE_COLOR": "3"}, false, ColorLevelTrueColor},
+		{map[string]string{"FORCE_COLOR": "1"}, false, ColorLevel16},
+		{map[string]string{"FORCE_COLOR": "0", "COLORTERM": "truecolor"}, true, ColorLevelNone},
+	}
+
+	for _, test := range tests {
+		getenv := func(key string) string { return test.env[key] }
+		if actual := detectColorLevel(getenv, test.terminal); actual != test.expected {
+			t.Errorf("\nExpected: %d\nActual: %d\nEnvironment: %v", test.expected, actual, test.env)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package gochalk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Class of a piece of source code, used to pick its style from a theme
+type SyntaxClass int
+
+const (
+	// Identifiers, whitespace and anything else without a class
+	SyntaxText SyntaxClass = iota
+	SyntaxKeyword
+	// Built-in types
+	SyntaxType
+	// Built-in functions and shell commands
+	SyntaxBuiltin
+	SyntaxString
+	SyntaxNumber
+	// Values such as true, false and null
+	SyntaxConstant
+	SyntaxComment
+	SyntaxOperator
+	SyntaxPunctuation
+	// Keys of JSON objects, YAML mappings and INI files
+	SyntaxKey
+	// INI section headers
+	SyntaxSection
+	// Shell variables and SQL parameters
+	SyntaxVariable
+)
+
+// Names of syntax classes, used as theme keys
+var syntaxClassNames = []string{
+	"text", "keyword", "type", "builtin", "string", "number", "constant", "comment", "operator", "punctuation",
+	"key", "section", "variable",
+}
+
+// Piece of source code with its class
+type SyntaxToken struct {
+	Class SyntaxClass
+	Text  string
+}
+
+// Splits source code into classified tokens. Joining the text of the tokens must give the source back
+type Lexer interface {
+	Tokenize(source string) []SyntaxToken
+}
+
+// Regular expression matched by a RuleLexer
+type LexerRule struct {
+	// Regular expression, matched at the current position only
+	Pattern string
+	// Class of the match. Ignored when Groups is set
+	Class SyntaxClass
+	// Classes of the groups of Pattern, for rules matching several tokens at once such as a key and its colon.
+	// Text matched outside groups is SyntaxText
+	Groups []SyntaxClass
+	// Classes of specific matches, such as keywords among identifiers
+	Words map[string]SyntaxClass
+	// Compare matches to Words ignoring case. Words must then be lowercase
+	IgnoreCase bool
+	// Only match at the start of a line, after indentation
+	LineStart bool
+}
+
+// Lexer trying a list of rules in order at each position. Text matched by no rule is SyntaxText
+type RuleLexer struct {
+	rules    []LexerRule
+	patterns []*regexp.Regexp
+}
+
+// Options for highlighting source code
+type HighlightOptions struct {
+	// Styles by class name, e.g. "keyword" or "string". Classes missing from the theme are written plain.
+	// Defaults to DefaultSyntaxTheme
+	Theme Theme
+	// Color level of the output, detected for stdout by default. Source is returned as is with ColorLevelNone
+	Level ColorLevel
+}
+
+var (
+	lexerMutex sync.RWMutex
+	lexers     = map[string]Lexer{
+		"go": goLexer, "golang": goLexer,
+		"json": jsonLexer,
+		"yaml": yamlLexer, "yml": yamlLexer,
+		"sql": sqlLexer,
+		"sh":  shellLexer, "bash": shellLexer, "shell": shellLexer, "zsh": shellLexer,
+		"ini": iniLexer, "cfg": iniLexer, "conf": iniLexer,
+	}
+)
+
+// Method to get the name of a syntax class, as used for theme keys
+func (class SyntaxClass) String() string {
+	if class < 0 || int(class) >= len(syntaxClassNames) {
+		return fmt.Sprintf("SyntaxClass(%d)", int(class))
+	}
+
+	return syntaxClassNames[class]
+}
+
+// Creates a new RuleLexer. Returns an error if a pattern doesn't compile
+//
+//	lexer, err := gochalk.NewRuleLexer([]gochalk.LexerRule{
+//		{Pattern: `#[^\n]*`, Class: gochalk.SyntaxComment},
+//		{Pattern: `"[^"\n]*"`, Class: gochalk.SyntaxString},
+//		{Pattern: `[a-z]+`, Words: map[string]gochalk.SyntaxClass{"rule": gochalk.SyntaxKeyword}},
+//	})
+func NewRuleLexer(rules []LexerRule) (*RuleLexer, error) {
+	lexer := &RuleLexer{rules: rules}
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(`^(?:` + rule.Pattern + `)`)
+		if err != nil {
+			return nil, fmt.Errorf("gochalk: invalid lexer rule: %w", err)
+		}
+		lexer.patterns = append(lexer.patterns, pattern)
+	}
+
+	return lexer, nil
+}
+
+// Method to split source into tokens using the rules of the lexer. Neighbouring tokens of the same class are merged
+func (lexer *RuleLexer) Tokenize(source string) []SyntaxToken {
+	// Tokens are collected as ranges of source, so merging them doesn't copy text
+	type span struct {
+		class      SyntaxClass
+		start, end int
+	}
+	var spans []span
+	add := func(class SyntaxClass, start int, end int) {
+		if start == end {
+			return
+		}
+		if last := len(spans) - 1; last >= 0 && spans[last].class == class {
+			spans[last].end = end
+			return
+		}
+		spans = append(spans, span{class: class, start: start, end: end})
+	}
+
+	// Whether only indentation was read on the current line
+	indented := true
+	for position := 0; position < len(source); {
+		size := 0
+		for index, pattern := range lexer.patterns {
+			rule := lexer.rules[index]
+			if rule.LineStart && !indented {
+				continue
+			}
+			match := pattern.FindStringSubmatchIndex(source[position:])
+			if match == nil || match[1] == 0 {
+				continue
+			}
+
+			if len(rule.Groups) == 0 {
+				add(rule.classify(source[position:position+match[1]]), position, position+match[1])
+			} else {
+				// Groups that didn't match have negative indices
+				end := 0
+				for group, class := range rule.Groups {
+					start, stop := match[2*group+2], match[2*group+3]
+					if start < 0 {
+						continue
+					}
+					add(SyntaxText, position+end, position+start)
+					add(class, position+start, position+stop)
+					end = stop
+				}
+				add(SyntaxText, position+end, position+match[1])
+			}
+
+			size = match[1]
+			break
+		}
+		if size == 0 {
+			_, size = utf8.DecodeRuneInString(source[position:])
+			add(SyntaxText, position, position+size)
+		}
+
+		read := source[position : position+size]
+		if newline := strings.LastIndexByte(read, '\n'); newline >= 0 {
+			indented, read = true, read[newline+1:]
+		}
+		indented = indented && strings.Trim(read, " \t") == ""
+		position += size
+	}
+
+	tokens := make([]SyntaxToken, len(spans))
+	for index, span := range spans {
+		tokens[index] = SyntaxToken{Class: span.class, Text: source[span.start:span.end]}
+	}
+
+	return tokens
+}
+
+// Method to get the class of text matched by the rule
+func (rule LexerRule) classify(text string) SyntaxClass {
+	if rule.IgnoreCase {
+		text = strings.ToLower(text)
+	}
+	if class, ok := rule.Words[text]; ok {
+		return class
+	}
+
+	return rule.Class
+}
+
+// Method to register a lexer under a language name, replacing any lexer registered under that name.
+// Names are compared ignoring case
+//
+//	gochalk.RegisterLexer("hcl", hclLexer)
+func RegisterLexer(language string, lexer Lexer) {
+	lexerMutex.Lock()
+	defer lexerMutex.Unlock()
+
+	lexers[strings.ToLower(language)] = lexer
+}
+
+// Method to get the lexer registered for a language, or nil if there is none. Built-in lexers are registered for
+// go, json, yaml, sql, sh and ini, along with aliases such as golang, yml, bash and conf
+func LookupLexer(language string) Lexer {
+	lexerMutex.RLock()
+	defer lexerMutex.RUnlock()
+
+	return lexers[strings.ToLower(language)]
+}
+
+// Method to get the default theme for syntax highlighting. Each call returns a new Theme, so it can be changed freely
+func DefaultSyntaxTheme() Theme {
+	return Theme{
+		"keyword":  NewStyle(FgMagenta),
+		"type":     NewStyle(FgCyan),
+		"builtin":  NewStyle(FgBlue),
+		"string":   NewStyle(FgGreen),
+		"number":   NewStyle(FgYellow),
+		"constant": NewStyle(FgYellow),
+		"comment":  NewStyle(FgBrightBlack, Italics),
+		"key":      NewStyle(FgBlue),
+		"section":  NewStyle(FgMagenta, Bold),
+		"variable": NewStyle(FgCyan),
+	}
+}
+
+// Method to highlight source code of a language using the lexer registered for it. Source of languages without a
+// lexer is returned unchanged. Styles end at the end of each line, so the result can be wrapped or put in boxes.
+// Passing nil options uses DefaultSyntaxTheme
+//
+//	fmt.Println(gochalk.Highlight(query, "sql", nil))
+func Highlight(source string, language string, options *HighlightOptions) string {
+	lexer := LookupLexer(language)
+	if lexer == nil {
+		return source
+	}
+
+	return HighlightTokens(lexer.Tokenize(source), options)
+}
+
+// Method to style tokens, e.g. from a lexer that isn't registered
+func HighlightTokens(tokens []SyntaxToken, options *HighlightOptions) string {
+	options = options.withDefaults()
+
+	var builder strings.Builder
+	for _, token := range tokens {
+		chalk := options.Theme[token.Class.String()]
+		for index, line := range strings.Split(token.Text, "\n") {
+			if index > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(paintStyle(options.Level, chalk, line))
+		}
+	}
+
+	return builder.String()
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *HighlightOptions) withDefaults() *HighlightOptions {
+	result := HighlightOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Theme == nil {
+		result.Theme = DefaultSyntaxTheme()
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	return &result
+}
+
+// Method to create a RuleLexer for the built-in lexers, whose rules are known to compile
+func mustRuleLexer(rules []LexerRule) *RuleLexer {
+	lexer, err := NewRuleLexer(rules)
+	if err != nil {
+		panic(err)
+	}
+
+	return lexer
+}
@@ -0,0 +1,111 @@
+package gochalk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Method to describe tokens as class"text" pairs. Whitespace around text tokens is trimmed and tokens that are only
+// whitespace are left out
+func describeTokens(tokens []SyntaxToken) string {
+	var parts []string
+	for _, token := range tokens {
+		text := token.Text
+		if token.Class == SyntaxText {
+			if text = strings.TrimSpace(text); text == "" {
+				continue
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%s%q", token.Class, text))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func TestNewRuleLexer(t *testing.T) {
+	lexer, err := NewRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `#[^\n]*`, Class: SyntaxComment, LineStart: true},
+		{Pattern: `(\w+)( *)(=)`, Groups: []SyntaxClass{SyntaxKey, SyntaxText, SyntaxOperator}},
+		{Pattern: `\w+`, Words: map[string]SyntaxClass{"on": SyntaxConstant}, IgnoreCase: true},
+	})
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+
+	source := "# settings\nmode = ON # not a comment\n  # indented"
+	tokens := lexer.Tokenize(source)
+	actualString := describeTokens(tokens)
+	expectedString := `comment"# settings" key"mode" operator"=" constant"ON" text"# not a comment" comment"# indented"`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+
+	var joined strings.Builder
+	for _, token := range tokens {
+		joined.WriteString(token.Text)
+	}
+	if strings.Compare(joined.String(), source) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", source, joined.String())
+	}
+
+	if _, err := NewRuleLexer([]LexerRule{{Pattern: `(`}}); err == nil {
+		t.Errorf("\nExpected: error\nActual: nil")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	actualString := Highlight("SELECT 1 /* a\nb */", "SQL", &HighlightOptions{Level: ColorLevel16})
+	comment := NewStyle(FgBrightBlack, Italics)
+	expectedString := NewStyle(FgMagenta).ToString("SELECT") + " " + NewStyle(FgYellow).ToString("1") + " " +
+		comment.ToString("/* a") + "\n" + comment.ToString("b */")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	theme := Theme{"keyword": NewStyle(FgRed)}
+	actualString = Highlight("SELECT 1", "sql", &HighlightOptions{Theme: theme, Level: ColorLevel16})
+	expectedString = NewStyle(FgRed).ToString("SELECT") + " 1"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Highlight("SELECT 1", "sql", &HighlightOptions{Level: ColorLevelNone})
+	if strings.Compare(actualString, "SELECT 1") != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", "SELECT 1", actualString)
+	}
+
+	actualString = Highlight("x := 1", "cobol", &HighlightOptions{Level: ColorLevel16})
+	if strings.Compare(actualString, "x := 1") != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", "x := 1", actualString)
+	}
+}
+
+func TestRegisterLexer(t *testing.T) {
+	lexer, _ := NewRuleLexer([]LexerRule{{Pattern: `[A-Z]+`, Class: SyntaxKeyword}})
+	RegisterLexer("Shout", lexer)
+	defer func() {
+		lexerMutex.Lock()
+		delete(lexers, "shout")
+		lexerMutex.Unlock()
+	}()
+
+	if LookupLexer("SHOUT") != Lexer(lexer) {
+		t.Errorf("\nExpected: registered lexer\nActual: %v", LookupLexer("SHOUT"))
+	}
+	actualString := Highlight("HEY you", "shout", &HighlightOptions{Level: ColorLevel16})
+	expectedString := NewStyle(FgMagenta).ToString("HEY") + " you"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSyntaxClass_String(t *testing.T) {
+	if actualString := SyntaxVariable.String(); actualString != "variable" {
+		t.Errorf("\nExpected: %q\nActual: %q", "variable", actualString)
+	}
+	if actualString := SyntaxClass(99).String(); actualString != "SyntaxClass(99)" {
+		t.Errorf("\nExpected: %q\nActual: %q", "SyntaxClass(99)", actualString)
+	}
+}
@@ -0,0 +1,210 @@
+package gochalk
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// Options for converting styled text to HTML
+type HTMLOptions struct {
+	// Use CSS classes instead of inline styles. Stylesheet for the classes can be generated using HTMLStylesheet.
+	// 24-bit colors are always written as inline styles
+	UseClasses bool
+	// Prefix added to generated class names. Defaults to "gochalk-"
+	ClassPrefix string
+	// Palette used to resolve basic and 256 colors. Defaults to the palette set using SetPalette
+	Palette *Palette
+}
+
+// URL schemes allowed in hyperlinks. Links with any other scheme are rendered as plain text
+var htmlLinkSchemes = []string{"http", "https", "mailto", "ftp", "file"}
+
+// Method to convert text containing SGR escape sequences to HTML. Styled text is wrapped in <span> elements and
+// OSC 8 hyperlinks are converted to <a> elements. Text is HTML escaped and other escape sequences are dropped.
+// Passing nil options uses inline styles with the palette set using SetPalette
+//
+//	gochalk.ANSIToHTML(gochalk.Red("Error"), nil) // <span style="color:#cd0000">Error</span>
+func ANSIToHTML(value string, options *HTMLOptions) string {
+	options = options.withDefaults()
+
+	var builder strings.Builder
+	var state sgrState
+	var openSpan, openLink, link string
+	spanOpened, linkOpened := false, false
+
+	closeSpan := func() {
+		if spanOpened {
+			builder.WriteString("</span>")
+			spanOpened = false
+		}
+	}
+	closeLink := func() {
+		closeSpan()
+		if linkOpened {
+			builder.WriteString("</a>")
+			linkOpened = false
+		}
+	}
+
+	writeText := func(text string) {
+		if linkOpened && openLink != link {
+			closeLink()
+		}
+		if !linkOpened && link != "" {
+			closeSpan()
+			fmt.Fprintf(&builder, `<a href="%s">`, html.EscapeString(link))
+			openLink, linkOpened = link, true
+		}
+
+		span := options.spanAttributes(state)
+		if spanOpened && openSpan != span {
+			closeSpan()
+		}
+		if !spanOpened && span != "" {
+			fmt.Fprintf(&builder, "<span %s>", span)
+			openSpan, spanOpened = span, true
+		}
+		builder.WriteString(html.EscapeString(text))
+	}
+
+	for _, token := range Tokenize(value) {
+		switch token.Kind {
+		case TokenText:
+			writeText(token.Raw)
+		case TokenControl:
+			if token.Raw == "\n" || token.Raw == "\t" {
+				writeText(token.Raw)
+			}
+		case TokenSGR:
+			state.apply(token.Styles)
+		case TokenOSC:
+			if target, _, ok := token.Hyperlink(); ok {
+				if !isAllowedLink(target) {
+					target = ""
+				}
+				link = target
+			}
+		}
+	}
+	closeLink()
+
+	return builder.String()
+}
+
+// Method to generate a CSS stylesheet with the classes used when HTMLOptions.UseClasses is set
+func HTMLStylesheet(options *HTMLOptions) string {
+	options = options.withDefaults()
+	prefix := options.ClassPrefix
+
+	var builder strings.Builder
+	for index := 0; index < 256; index++ {
+		hex := options.Palette.Color256(uint8(index)).Hex()
+		fmt.Fprintf(&builder, ".%sfg-%d { color: %s; }\n", prefix, index, hex)
+		fmt.Fprintf(&builder, ".%sbg-%d { background-color: %s; }\n", prefix, index, hex)
+	}
+	fmt.Fprintf(&builder, ".%sbold { font-weight: bold; }\n", prefix)
+	fmt.Fprintf(&builder, ".%sdim { opacity: 0.5; }\n", prefix)
+	fmt.Fprintf(&builder, ".%sitalic { font-style: italic; }\n", prefix)
+	fmt.Fprintf(&builder, ".%sunderline { text-decoration: underline; }\n", prefix)
+	fmt.Fprintf(&builder, ".%sstrikethrough { text-decoration: line-through; }\n", prefix)
+	fmt.Fprintf(&builder, ".%sunderline.%sstrikethrough { text-decoration: underline line-through; }\n", prefix, prefix)
+	fmt.Fprintf(&builder, ".%shidden { visibility: hidden; }\n", prefix)
+
+	return builder.String()
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *HTMLOptions) withDefaults() *HTMLOptions {
+	result := HTMLOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.ClassPrefix == "" {
+		result.ClassPrefix = "gochalk-"
+	}
+	if result.Palette == nil {
+		palette := CurrentPalette()
+		result.Palette = &palette
+	}
+
+	return &result
+}
+
+// Method to get the attributes of the <span> used for given state. Returns empty string for unstyled text
+func (options *HTMLOptions) spanAttributes(state sgrState) string {
+	if state.isDefault() {
+		return ""
+	}
+
+	fg, bg := state.colors()
+	var classes, styles []string
+
+	addColor := func(spec colorSpec, classKind string, property string) {
+		switch {
+		case spec.kind == colorDefault:
+			return
+		case spec.kind == colorIndexed && options.UseClasses:
+			classes = append(classes, fmt.Sprintf("%s%s-%d", options.ClassPrefix, classKind, spec.index))
+		default:
+			styles = append(styles, property+":"+spec.resolve(options.Palette).Hex())
+		}
+	}
+	addColor(fg, "fg", "color")
+	addColor(bg, "bg", "background-color")
+
+	addFlag := func(enabled bool, class string, style string) {
+		if !enabled {
+			return
+		}
+		if options.UseClasses {
+			classes = append(classes, options.ClassPrefix+class)
+		} else if style != "" {
+			styles = append(styles, style)
+		}
+	}
+	addFlag(state.bold, "bold", "font-weight:bold")
+	addFlag(state.dim, "dim", "opacity:0.5")
+	addFlag(state.italic, "italic", "font-style:italic")
+	addFlag(state.hidden, "hidden", "visibility:hidden")
+	addFlag(state.underline, "underline", "")
+	addFlag(state.strikethrough, "strikethrough", "")
+
+	if !options.UseClasses && (state.underline || state.strikethrough) {
+		var decorations []string
+		if state.underline {
+			decorations = append(decorations, "underline")
+		}
+		if state.strikethrough {
+			decorations = append(decorations, "line-through")
+		}
+		styles = append(styles, "text-decoration:"+strings.Join(decorations, " "))
+	}
+
+	var attributes []string
+	if len(classes) > 0 {
+		attributes = append(attributes, fmt.Sprintf(`class="%s"`, strings.Join(classes, " ")))
+	}
+	if len(styles) > 0 {
+		attributes = append(attributes, fmt.Sprintf(`style="%s"`, strings.Join(styles, ";")))
+	}
+
+	return strings.Join(attributes, " ")
+}
+
+// Method to check if hyperlink target uses a scheme which is safe to put in a web page
+func isAllowedLink(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	for _, scheme := range htmlLinkSchemes {
+		if strings.EqualFold(parsed.Scheme, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestANSIToHTML_InlineStyles(t *testing.T) {
+	actualString := ANSIToHTML(StyledString(testString, Bold, FgRed), nil)
+	expectedString := `<span style="color:#cd0000;font-weight:bold">Test String</span>`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestANSIToHTML_Classes(t *testing.T) {
+	options := &HTMLOptions{UseClasses: true, ClassPrefix: "c-"}
+
+	actualString := ANSIToHTML(StyledString(testString, Underlined, BgBrightBlue), options)
+	expectedString := `<span class="c-bg-12 c-underline">Test String</span>`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestANSIToHTML_Nested(t *testing.T) {
+	actualString := ANSIToHTML(Green("Green", Red("Red"), "String"), nil)
+	expectedString := `<span style="color:#00cd00">Green </span><span style="color:#cd0000">Red</span><span style="color:#00cd00"> String</span>`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestANSIToHTML_ExtendedColors(t *testing.T) {
+	actualString := ANSIToHTML("\x1b[38;5;196;48;2;1;2;3mX\x1b[39mY\x1b[0m", nil)
+	expectedString := `<span style="color:#ff0000;background-color:#010203">X</span><span style="background-color:#010203">Y</span>`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestANSIToHTML_Escaping(t *testing.T) {
+	actualString := ANSIToHTML(Red("<b>&</b>")+"\x07\x1b[2K", nil)
+	expectedString := `<span style="color:#cd0000">&lt;b&gt;&amp;&lt;/b&gt;</span>`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestANSIToHTML_Hyperlink(t *testing.T) {
+	value := "\x1b]8;;https://example.com/?a=1&b=2\x1b\\" + Red("link") + "\x1b]8;;\x1b\\ text"
+
+	actualString := ANSIToHTML(value, nil)
+	expectedString := `<a href="https://example.com/?a=1&amp;b=2"><span style="color:#cd0000">link</span></a> text`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestANSIToHTML_UnsafeHyperlink(t *testing.T) {
+	actualString := ANSIToHTML("\x1b]8;;javascript:alert(1)\x07click\x1b]8;;\x07", nil)
+	expectedString := "click"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestHTMLStylesheet(t *testing.T) {
+	stylesheet := HTMLStylesheet(&HTMLOptions{Palette: &Palette{1: {0x12, 0x34, 0x56}}})
+
+	if !strings.Contains(stylesheet, ".gochalk-fg-1 { color: #123456; }") {
+		t.Errorf("\nExpected: Stylesheet to use custom palette\nActual: %s", stylesheet)
+	}
+	if !strings.Contains(stylesheet, ".gochalk-bg-196 { background-color: #ff0000; }") {
+		t.Error("\nExpected: Stylesheet to contain 256 colors\nActual: 256 colors missing")
+	}
+}
@@ -0,0 +1,105 @@
+package gochalk
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	hyperlinkMutex    sync.RWMutex
+	hyperlinkOverride *bool
+	detectHyperlinks  = sync.OnceValue(func() bool {
+		return detectHyperlinkSupport(os.Getenv, isTerminal(os.Stdout))
+	})
+)
+
+// Method to check if hyperlinks are rendered as OSC 8 escape sequences. Support is detected from the
+// environment of stdout unless set using SetHyperlinkSupport
+func SupportsHyperlinks() bool {
+	hyperlinkMutex.RLock()
+	defer hyperlinkMutex.RUnlock()
+
+	if hyperlinkOverride != nil {
+		return *hyperlinkOverride
+	}
+
+	return detectHyperlinks()
+}
+
+// Method to enable or disable OSC 8 hyperlinks regardless of terminal detection
+func SetHyperlinkSupport(enabled bool) {
+	hyperlinkMutex.Lock()
+	defer hyperlinkMutex.Unlock()
+
+	hyperlinkOverride = &enabled
+}
+
+// Method to undo SetHyperlinkSupport, going back to detecting support from the environment of stdout
+func ResetHyperlinkSupport() {
+	hyperlinkMutex.Lock()
+	defer hyperlinkMutex.Unlock()
+
+	hyperlinkOverride = nil
+}
+
+// Method to render a clickable hyperlink. When the terminal isn't known to support hyperlinks "text (url)" is returned.
+// If text is empty the url is used as text
+//
+//	fmt.Println("See", gochalk.Hyperlink("https://go.dev", "the Go website"))
+func Hyperlink(url string, text string) string {
+	return NewStyle().HyperlinkWithID("", url, text)
+}
+
+// Method to render a clickable hyperlink with an id. Terminals highlight all parts of a link sharing the same id
+// together, which keeps links spanning multiple lines working
+func HyperlinkWithID(id string, url string, text string) string {
+	return NewStyle().HyperlinkWithID(id, url, text)
+}
+
+// Method to render a clickable hyperlink with text wrapped in styles present in Chalk
+//
+//	gochalk.NewStyle(gochalk.FgBlue, gochalk.Underlined).Hyperlink("https://go.dev", "Go")
+func (chalk *Chalk) Hyperlink(url string, text ...string) string {
+	return chalk.HyperlinkWithID("", url, text...)
+}
+
+// Method to render a clickable hyperlink with an id and text wrapped in styles present in Chalk.
+// Each line of multi-line text is linked separately so styles and links don't leak into following lines
+func (chalk *Chalk) HyperlinkWithID(id string, url string, text ...string) string {
+	url = Sanitize(url, SanitizeStrip)
+	value := combineStrings(text...)
+	if value == "" {
+		value = url
+	}
+
+	if !SupportsHyperlinks() {
+		if value == url {
+			return chalk.ToString(value)
+		}
+		return fmt.Sprintf("%s (%s)", chalk.ToString(value), url)
+	}
+
+	lines := strings.Split(value, "\n")
+	for index, line := range lines {
+		lines[index] = hyperlinkStart(id, url) + chalk.ToString(line) + hyperlinkEnd()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Method to get the OSC 8 sequence starting a hyperlink
+func hyperlinkStart(id string, url string) string {
+	var params string
+	if id != "" {
+		params = "id=" + strings.NewReplacer(":", "", ";", "").Replace(Sanitize(id, SanitizeStrip))
+	}
+
+	return fmt.Sprintf("%s]8;%s;%s%s\\", escape, params, url, escape)
+}
+
+// Method to get the OSC 8 sequence ending a hyperlink
+func hyperlinkEnd() string {
+	return hyperlinkStart("", "")
+}
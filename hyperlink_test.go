@@ -0,0 +1,108 @@
+package gochalk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Method to set hyperlink support for a test, restoring the previous setting when it ends
+func setTestHyperlinkSupport(t *testing.T, enabled bool) {
+	hyperlinkMutex.RLock()
+	previous := hyperlinkOverride
+	hyperlinkMutex.RUnlock()
+	t.Cleanup(func() {
+		hyperlinkMutex.Lock()
+		hyperlinkOverride = previous
+		hyperlinkMutex.Unlock()
+	})
+
+	SetHyperlinkSupport(enabled)
+}
+
+func TestResetHyperlinkSupport(t *testing.T) {
+	setTestHyperlinkSupport(t, !detectHyperlinks())
+	if SupportsHyperlinks() == detectHyperlinks() {
+		t.Error("\nExpected: SetHyperlinkSupport to override detection\nActual: Detected support was used")
+	}
+
+	ResetHyperlinkSupport()
+	if SupportsHyperlinks() != detectHyperlinks() {
+		t.Error("\nExpected: ResetHyperlinkSupport to go back to detection\nActual: Override was kept")
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	setTestHyperlinkSupport(t, true)
+
+	actualString := Hyperlink("https://go.dev", "Go")
+	expectedString := "\x1b]8;;https://go.dev\x1b\\Go\x1b]8;;\x1b\\"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestHyperlink_Fallback(t *testing.T) {
+	setTestHyperlinkSupport(t, false)
+
+	actualString := Hyperlink("https://go.dev", "Go")
+	expectedString := "Go (https://go.dev)"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = Hyperlink("https://go.dev", "")
+	expectedString = "https://go.dev"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestChalkHyperlink_MultiLine(t *testing.T) {
+	setTestHyperlinkSupport(t, true)
+
+	actualString := NewStyle(FgBlue).HyperlinkWithID("a;b", "https://go.dev", "line 1\nline 2")
+	start := "\x1b]8;id=ab;https://go.dev\x1b\\"
+	end := "\x1b]8;;\x1b\\"
+	expectedString := fmt.Sprintf("%s%s%s\n%s%s%s", start, Blue("line 1"), end, start, Blue("line 2"), end)
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestHyperlink_SanitizesURL(t *testing.T) {
+	setTestHyperlinkSupport(t, true)
+
+	actualString := Hyperlink("https://go.dev/\x1b\\\x1b[2J", "Go")
+	expectedString := "\x1b]8;;https://go.dev/\x1b\\Go\x1b]8;;\x1b\\"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestDetectHyperlinkSupport(t *testing.T) {
+	cases := []struct {
+		env      map[string]string
+		terminal bool
+		expected bool
+	}{
+		{env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, terminal: true, expected: true},
+		{env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, terminal: false, expected: false},
+		{env: map[string]string{"VTE_VERSION": "6003"}, terminal: true, expected: true},
+		{env: map[string]string{"VTE_VERSION": "4000"}, terminal: true, expected: false},
+		{env: map[string]string{"WT_SESSION": "1", "CI": "true"}, terminal: true, expected: false},
+		{env: map[string]string{"FORCE_HYPERLINK": "1"}, terminal: false, expected: true},
+		{env: map[string]string{"FORCE_HYPERLINK": "0", "TERM": "xterm-kitty"}, terminal: true, expected: false},
+		{env: map[string]string{"TERM": "xterm-256color"}, terminal: true, expected: false},
+	}
+
+	for _, testCase := range cases {
+		getenv := func(key string) string { return testCase.env[key] }
+		if actual := detectHyperlinkSupport(getenv, testCase.terminal); actual != testCase.expected {
+			t.Errorf("\nExpected: %t for %v\nActual: %t", testCase.expected, testCase.env, actual)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package gochalk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// Options for formatting JSON
+type JSONOptions struct {
+	// Indentation for each level. Defaults to two spaces
+	Indent string
+	// Styles for object keys, strings, numbers, true / false, null and brackets, commas and colons
+	Key         *Chalk
+	String      *Chalk
+	Number      *Chalk
+	Bool        *Chalk
+	Null        *Chalk
+	Punctuation *Chalk
+	// ColorLevelNone writes plain text. Defaults to the level detected for the writer, or stdout when formatting
+	// to a string
+	Level ColorLevel
+}
+
+// Method to read JSON from reader and write it indented and colored to writer. Tokens are written as they are read,
+// so large documents are never held in memory. Multiple values, as in JSON Lines, are written one after another.
+// Passing nil options uses jq-like styles
+//
+//	response, _ := http.Get(url)
+//	err := gochalk.WriteJSON(os.Stdout, response.Body, nil)
+func WriteJSON(writer io.Writer, reader io.Reader, options *JSONOptions) error {
+	options = options.withDefaults()
+	if options.Level == ColorLevelAuto {
+		options.Level = DetectColorLevel()
+		if file, ok := writer.(*os.File); ok {
+			options.Level = detectColorLevel(os.Getenv, isTerminal(file))
+		}
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	buffered := bufio.NewWriter(writer)
+	formatter := &jsonFormatter{decoder: decoder, writer: buffered, options: options}
+
+	var err error
+	for err == nil {
+		var token json.Token
+		if token, err = decoder.Token(); err == nil {
+			if err = formatter.value(token, 0); err == nil {
+				buffered.WriteString("\n")
+			}
+		}
+	}
+	if flushErr := buffered.Flush(); flushErr != nil {
+		return flushErr
+	}
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+// Method to format JSON data indented and colored
+//
+//	formatted, err := gochalk.FormatJSON(body, nil)
+func FormatJSON(data []byte, options *JSONOptions) (string, error) {
+	var builder strings.Builder
+	err := WriteJSON(&builder, bytes.NewReader(data), options)
+
+	return strings.TrimSuffix(builder.String(), "\n"), err
+}
+
+// Method to encode value to JSON and format it indented and colored
+//
+//	formatted, err := gochalk.FormatJSONValue(config, nil)
+func FormatJSONValue(value any, options *JSONOptions) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return FormatJSON(buffer.Bytes(), options)
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *JSONOptions) withDefaults() *JSONOptions {
+	result := JSONOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Indent == "" {
+		result.Indent = "  "
+	}
+
+	fillDefaultStyles([]defaultStyle{
+		{&result.Key, NewStyle(FgBlue, Bold)},
+		{&result.String, NewStyle(FgGreen)},
+		{&result.Number, NewStyle(FgCyan)},
+		{&result.Bool, NewStyle(FgYellow)},
+		{&result.Null, NewStyle(FgBrightBlack)},
+		{&result.Punctuation, NewStyle()},
+	})
+
+	return &result
+}
+
+// State of a JSON document being formatted
+type jsonFormatter struct {
+	decoder *json.Decoder
+	writer  *bufio.Writer
+	options *JSONOptions
+}
+
+// Method to write a value starting with token. Objects and arrays are read until their closing bracket
+func (formatter *jsonFormatter) value(token json.Token, depth int) error {
+	options := formatter.options
+	switch token := token.(type) {
+	case json.Delim:
+		return formatter.container(token, depth)
+	case string:
+		formatter.write(options.String, jsonQuote(token))
+	case json.Number:
+		formatter.write(options.Number, token.String())
+	case bool:
+		if token {
+			formatter.write(options.Bool, "true")
+		} else {
+			formatter.write(options.Bool, "false")
+		}
+	case nil:
+		formatter.write(options.Null, "null")
+	}
+
+	return nil
+}
+
+// Method to write an object or array. Empty ones are written on a single line
+func (formatter *jsonFormatter) container(open json.Delim, depth int) error {
+	options := formatter.options
+	closing := "]"
+	if open == '{' {
+		closing = "}"
+	}
+
+	formatter.write(options.Punctuation, open.String())
+	empty := true
+	for formatter.decoder.More() {
+		if !empty {
+			formatter.write(options.Punctuation, ",")
+		}
+		empty = false
+		formatter.writer.WriteString("\n" + strings.Repeat(options.Indent, depth+1))
+
+		token, err := formatter.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if open == '{' {
+			key, _ := token.(string)
+			formatter.write(options.Key, jsonQuote(key))
+			formatter.write(options.Punctuation, ":")
+			formatter.writer.WriteString(" ")
+			if token, err = formatter.decoder.Token(); err != nil {
+				return err
+			}
+		}
+		if err := formatter.value(token, depth+1); err != nil {
+			return err
+		}
+	}
+
+	// Read the closing bracket
+	if _, err := formatter.decoder.Token(); err != nil {
+		return err
+	}
+	if !empty {
+		formatter.writer.WriteString("\n" + strings.Repeat(options.Indent, depth))
+	}
+	formatter.write(options.Punctuation, closing)
+
+	return nil
+}
+
+// Method to write text in the given style, or plain when colors are off
+func (formatter *jsonFormatter) write(chalk *Chalk, text string) {
+	formatter.writer.WriteString(paintStyle(formatter.options.Level, chalk, text))
+}
+
+// Method to quote a string the way encoding/json does, without escaping HTML characters
+func jsonQuote(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
@@ -0,0 +1,88 @@
+package gochalk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatJSON(t *testing.T) {
+	data := `{"name":"gochalk","stars":1.5e3,"tags":["cli","<color>"],"empty":{},"none":[],"ok":true,"parent":null}`
+
+	actualString, err := FormatJSON([]byte(data), &JSONOptions{Level: ColorLevelNone})
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+	expectedString := `{
+  "name": "gochalk",
+  "stars": 1.5e3,
+  "tags": [
+    "cli",
+    "<color>"
+  ],
+  "empty": {},
+  "none": [],
+  "ok": true,
+  "parent": null
+}`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestFormatJSON_Styles(t *testing.T) {
+	actualString, err := FormatJSON([]byte(`{"a":[1,"x",false,null]}`), &JSONOptions{Level: ColorLevel16, Indent: "\t"})
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+
+	expectedString := "{\n\t" + NewStyle(FgBlue, Bold).ToString(`"a"`) + ": [\n\t\t" +
+		NewStyle(FgCyan).ToString("1") + ",\n\t\t" +
+		NewStyle(FgGreen).ToString(`"x"`) + ",\n\t\t" +
+		NewStyle(FgYellow).ToString("false") + ",\n\t\t" +
+		NewStyle(FgBrightBlack).ToString("null") + "\n\t]\n}"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestWriteJSON_Stream(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteJSON(&buffer, strings.NewReader("{\"a\":1}\n[\"\\u001b[31m\"]\n"), &JSONOptions{Level: ColorLevelNone})
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+
+	actualString := buffer.String()
+	expectedString := "{\n  \"a\": 1\n}\n[\n  \"\\u001b[31m\"\n]\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	buffer.Reset()
+	err = WriteJSON(&buffer, strings.NewReader(`{"a": [1, }`), &JSONOptions{Level: ColorLevelNone})
+	if err == nil {
+		t.Error("\nExpected: Syntax error\nActual: nil")
+	}
+	if !strings.HasPrefix(buffer.String(), "{\n  \"a\": [\n    1") {
+		t.Errorf("\nExpected: Output written before the error\nActual: %q", buffer.String())
+	}
+}
+
+func TestFormatJSONValue(t *testing.T) {
+	value := struct {
+		Name  string   `json:"name"`
+		Ports []int    `json:"ports"`
+		Extra *float64 `json:"extra"`
+	}{"api", []int{80, 443}, nil}
+
+	actualString, err := FormatJSONValue(value, &JSONOptions{Level: ColorLevelNone})
+	expectedString := "{\n  \"name\": \"api\",\n  \"ports\": [\n    80,\n    443\n  ],\n  \"extra\": null\n}"
+	if err != nil || strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s (%v)", expectedString, actualString, err)
+	}
+
+	if _, err := FormatJSONValue(make(chan int), nil); err == nil {
+		t.Error("\nExpected: Error for unsupported value\nActual: nil")
+	}
+}
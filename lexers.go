@@ -0,0 +1,122 @@
+package gochalk
+
+import "strings"
+
+// End of a YAML scalar: trailing spaces followed by the end of the line, a flow separator or a comment
+const yamlScalarEnd = `([ \t]*)(?:$|(\n)|([,\]}])|(#[^\n]*))`
+
+// End of an INI value: trailing spaces followed by the end of the line
+const iniValueEnd = `([ \t]*)(?:$|(\n))`
+
+// Built-in lexers, registered in lexers
+var (
+	goLexer = mustRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `//[^\n]*|/\*(?s:.*?)\*/`, Class: SyntaxComment},
+		{Pattern: "`[^`]*`" + `|"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'`, Class: SyntaxString},
+		{Pattern: `0[xX][0-9a-fA-F_]+(?:\.[0-9a-fA-F_]*)?(?:[pP][+-]?\d+)?i?|0[bB][01_]+|0[oO][0-7_]+|` +
+			`(?:\d[\d_]*(?:\.[\d_]*)?|\.\d[\d_]*)(?:[eE][+-]?\d[\d_]*)?i?`, Class: SyntaxNumber},
+		{Pattern: `[\p{L}_][\p{L}\p{N}_]*`, Words: syntaxWords(map[SyntaxClass]string{
+			SyntaxKeyword: "break case chan const continue default defer else fallthrough for func go goto if import " +
+				"interface map package range return select struct switch type var",
+			SyntaxType: "any bool byte comparable complex64 complex128 error float32 float64 int int8 int16 int32 int64 " +
+				"rune string uint uint8 uint16 uint32 uint64 uintptr",
+			SyntaxBuiltin: "append cap clear close complex copy delete imag len make max min new panic print println " +
+				"real recover",
+			SyntaxConstant: "true false iota nil",
+		})},
+		{Pattern: `[-+*/%&|^<>=!:~.]+`, Class: SyntaxOperator},
+		{Pattern: `[(){}\[\],;]`, Class: SyntaxPunctuation},
+	})
+
+	jsonLexer = mustRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `("(?:[^"\\\n]|\\.)*")(\s*)(:)`, Groups: []SyntaxClass{SyntaxKey, SyntaxText, SyntaxPunctuation}},
+		{Pattern: `"(?:[^"\\\n]|\\.)*"`, Class: SyntaxString},
+		{Pattern: `-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?`, Class: SyntaxNumber},
+		{Pattern: `[a-z]+`, Words: syntaxWords(map[SyntaxClass]string{SyntaxConstant: "true false null"})},
+		{Pattern: `[{}\[\],:]`, Class: SyntaxPunctuation},
+	})
+
+	yamlLexer = mustRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `#[^\n]*`, Class: SyntaxComment},
+		{Pattern: `---|\.\.\.`, Class: SyntaxPunctuation, LineStart: true},
+		{Pattern: `(-[ \t]+)?("(?:[^"\\\n]|\\.)*"|'[^'\n]*'|[^\s#:'"\[\]{},&*!|>%@` + "`" + `-][^#\n:]*?)([ \t]*)(:)(?:[ \t]+|\n|$)`,
+			Groups: []SyntaxClass{SyntaxPunctuation, SyntaxKey, SyntaxText, SyntaxPunctuation}, LineStart: true},
+		{Pattern: `"(?:[^"\\]|\\.)*"|'(?:[^']|'')*'`, Class: SyntaxString},
+		{Pattern: `[&*][^\s,\[\]{}]+`, Class: SyntaxVariable},
+		{Pattern: `![^\s,\[\]{}]*`, Class: SyntaxType},
+		{Pattern: `([-+]?(?:0x[0-9a-fA-F]+|0o[0-7]+|(?:\d[\d_]*(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?|\.inf|\.nan))` + yamlScalarEnd,
+			Groups: []SyntaxClass{SyntaxNumber, SyntaxText, SyntaxText, SyntaxPunctuation, SyntaxComment}},
+		{Pattern: `(?i)(true|false|yes|no|on|off|null|~)` + yamlScalarEnd,
+			Groups: []SyntaxClass{SyntaxConstant, SyntaxText, SyntaxText, SyntaxPunctuation, SyntaxComment}},
+		{Pattern: `[-?:,\[\]{}|>]`, Class: SyntaxPunctuation},
+		// Plain scalars. # only starts a comment after whitespace
+		{Pattern: `[^\s#,\[\]{}](?:[^\s#,\[\]{}]|[ \t]+[^\s#,\[\]{}]|#)*`, Class: SyntaxString},
+	})
+
+	sqlLexer = mustRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `--[^\n]*|/\*(?s:.*?)\*/`, Class: SyntaxComment},
+		{Pattern: `'(?:[^']|'')*'`, Class: SyntaxString},
+		// Quoted identifiers
+		{Pattern: `"(?:[^"]|"")*"|` + "`[^`]*`"},
+		{Pattern: `::|[-+*/%<>=!|&^~]+`, Class: SyntaxOperator},
+		{Pattern: `\$\d+|[:@][\p{L}_][\p{L}\p{N}_]*|\?`, Class: SyntaxVariable},
+		{Pattern: `(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?`, Class: SyntaxNumber},
+		{Pattern: `[\p{L}_][\p{L}\p{N}_$]*`, IgnoreCase: true, Words: syntaxWords(map[SyntaxClass]string{
+			SyntaxKeyword: "add all alter analyze and as asc begin between by cascade case check column commit " +
+				"constraint create cross database default delete desc distinct drop else end exists explain foreign " +
+				"from full function grant group having if ilike in index inner insert intersect into is join key " +
+				"lateral left like limit natural not offset on or order outer primary procedure recursive references " +
+				"replace returning revoke right rollback schema select sequence set table then to transaction " +
+				"trigger truncate union unique update using values view when where with",
+			SyntaxType: "bigint bigserial blob bool boolean bytea char date decimal double float int integer interval " +
+				"json jsonb numeric precision real serial smallint text time timestamp timestamptz uuid varchar",
+			SyntaxBuiltin:  "avg cast coalesce count lower max min now nullif sum upper",
+			SyntaxConstant: "null true false",
+		})},
+		{Pattern: `[(),;.]`, Class: SyntaxPunctuation},
+	})
+
+	shellLexer = mustRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `#[^\n]*`, Class: SyntaxComment},
+		{Pattern: `'[^']*'|"(?:[^"\\]|\\.)*"`, Class: SyntaxString},
+		{Pattern: `\$\(|&&|\|\||;;|[|&;<>()]+`, Class: SyntaxOperator},
+		{Pattern: `\$(?:\{[^}\n]*\}|[\p{L}_][\p{L}\p{N}_]*|[0-9@*#?$!-])`, Class: SyntaxVariable},
+		{Pattern: `([\p{L}_][\p{L}\p{N}_]*)(\+?=)`, Groups: []SyntaxClass{SyntaxVariable, SyntaxOperator}},
+		// Words. # only starts a comment at the start of a word
+		{Pattern: `(?:[^\s;|&<>()$'"\\#]|\\.)(?:[^\s;|&<>()$'"\\]|\\.)*`, Words: syntaxWords(map[SyntaxClass]string{
+			SyntaxKeyword: "break case continue do done elif else esac fi for function if in return select then time " +
+				"until while",
+			SyntaxBuiltin: "alias cd command declare echo eval exec exit export false kill let local printf pwd read " +
+				"readonly set shift source test trap true type umask unset wait",
+		})},
+	})
+
+	iniLexer = mustRuleLexer([]LexerRule{
+		{Pattern: `\s+`},
+		{Pattern: `[;#][^\n]*`, Class: SyntaxComment, LineStart: true},
+		{Pattern: `\[[^\]\n]*\]`, Class: SyntaxSection, LineStart: true},
+		{Pattern: `([^\s=:;#\[][^=:\n]*?)([ \t]*)([=:])`,
+			Groups: []SyntaxClass{SyntaxKey, SyntaxText, SyntaxOperator}, LineStart: true},
+		{Pattern: `"(?:[^"\\\n]|\\.)*"|'[^'\n]*'`, Class: SyntaxString},
+		{Pattern: `(-?\d+(?:\.\d+)?)` + iniValueEnd, Groups: []SyntaxClass{SyntaxNumber, SyntaxText, SyntaxText}},
+		{Pattern: `(?i)(true|false|yes|no|on|off)` + iniValueEnd, Groups: []SyntaxClass{SyntaxConstant, SyntaxText, SyntaxText}},
+		{Pattern: `[^\s"'](?:[^\n]*[^\s])?`, Class: SyntaxString},
+	})
+)
+
+// Method to build a map of words to their classes from space separated lists
+func syntaxWords(lists map[SyntaxClass]string) map[string]SyntaxClass {
+	words := map[string]SyntaxClass{}
+	for class, list := range lists {
+		for _, word := range strings.Fields(list) {
+			words[word] = class
+		}
+	}
+
+	return words
+}
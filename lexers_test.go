@@ -0,0 +1,63 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLexers(t *testing.T) {
+	tests := []struct {
+		language string
+		source   string
+		expected string
+	}{
+		{
+			"go",
+			"func f(x int) error {\n\treturn fmt.Errorf(\"bad %d\", x+0x1F) // c\n}",
+			`keyword"func" text"f" punctuation"(" text"x" type"int" punctuation")" type"error" punctuation"{" ` +
+				`keyword"return" text"fmt" operator"." text"Errorf" punctuation"(" string"\"bad %d\"" punctuation"," ` +
+				`text"x" operator"+" number"0x1F" punctuation")" comment"// c" punctuation"}"`,
+		},
+		{
+			"json",
+			`{"a": [1, -2.5e3, true, null], "b": "x\"y"}`,
+			`punctuation"{" key"\"a\"" punctuation":" punctuation"[" number"1" punctuation"," number"-2.5e3" ` +
+				`punctuation"," constant"true" punctuation"," constant"null" punctuation"]," key"\"b\"" punctuation":" ` +
+				`string"\"x\\\"y\"" punctuation"}"`,
+		},
+		{
+			"yaml",
+			"server:\n  port: 80 # http\n  - url: http://x/#top\n  v: 1.2.3\n  ok: yes",
+			`key"server" punctuation":" key"port" punctuation":" number"80" comment"# http" punctuation"- " ` +
+				`key"url" punctuation":" string"http://x/#top" key"v" punctuation":" string"1.2.3" key"ok" ` +
+				`punctuation":" constant"yes"`,
+		},
+		{
+			"sql",
+			"select count(*) from users where name = 'O''Brien' and age > $1::int -- c",
+			`keyword"select" builtin"count" punctuation"(" operator"*" punctuation")" keyword"from" text"users" ` +
+				`keyword"where" text"name" operator"=" string"'O''Brien'" keyword"and" text"age" operator">" ` +
+				`variable"$1" operator"::" type"int" comment"-- c"`,
+		},
+		{
+			"bash",
+			"FOO=bar\nif [ -n \"$FOO\" ]; then echo ${FOO} a#b | grep x; fi # done",
+			`variable"FOO" operator"=" text"bar" keyword"if" text"[ -n" string"\"$FOO\"" text"]" operator";" ` +
+				`keyword"then" builtin"echo" variable"${FOO}" text"a#b" operator"|" text"grep x" operator";" ` +
+				`keyword"fi" comment"# done"`,
+		},
+		{
+			"ini",
+			"; c\n[server]\nport = 8080\nhost=localhost\ndebug = true",
+			`comment"; c" section"[server]" key"port" operator"=" number"8080" key"host" operator"=" ` +
+				`string"localhost" key"debug" operator"=" constant"true"`,
+		},
+	}
+
+	for _, test := range tests {
+		actualString := describeTokens(LookupLexer(test.language).Tokenize(test.source))
+		if strings.Compare(actualString, test.expected) != 0 {
+			t.Errorf("\n%s\nExpected: %s\nActual: %s", test.language, test.expected, actualString)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package gochalk
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Colors used by GNU ls when LS_COLORS isn't set
+const defaultLSColors = "rs=0:di=01;34:ln=01;36:mh=00:pi=40;33:so=01;35:do=01;35:bd=40;33;01:cd=40;33;01:" +
+	"or=40;31;01:mi=00:su=37;41:sg=30;43:ca=00:tw=30;42:ow=34;42:st=37;44:ex=01;32"
+
+// Keywords of dircolors databases and the LS_COLORS keys they set
+var dircolorsKeywords = map[string]string{
+	"NORMAL": "no", "NORM": "no", "FILE": "fi", "RESET": "rs", "DIR": "di", "LNK": "ln", "LINK": "ln",
+	"SYMLINK": "ln", "ORPHAN": "or", "MISSING": "mi", "FIFO": "pi", "PIPE": "pi", "SOCK": "so", "BLK": "bd",
+	"BLOCK": "bd", "CHR": "cd", "CHAR": "cd", "DOOR": "do", "EXEC": "ex", "SETUID": "su", "SETGID": "sg",
+	"STICKY": "st", "OTHER_WRITABLE": "ow", "OWR": "ow", "STICKY_OTHER_WRITABLE": "tw", "OWT": "tw",
+	"CAPABILITY": "ca", "MULTIHARDLINK": "mh",
+}
+
+// Styles for file types and file name patterns as used by ls --color
+type LSColors struct {
+	// Styles by two letter file type key, e.g. "di" for directories
+	types map[string]*Chalk
+	// Styles for file name suffixes such as ".tar", in the order they were defined
+	patterns []lsPattern
+	// Set by "ln=target", styling links like the file they point to
+	linkAsTarget bool
+}
+
+// Style for file names ending in suffix
+type lsPattern struct {
+	suffix string
+	chalk  *Chalk
+}
+
+// Method to convert SGR parameters such as "01;34" or "38;5;208" to a Chalk.
+// Invalid parameters and resets are ignored, so an empty string or "0" gives a Chalk without styles
+//
+//	gochalk.ParseSGR("01;31").Println("Error") // Bold red
+func ParseSGR(value string) *Chalk {
+	if strings.TrimSpace(value) == "" {
+		return NewStyle()
+	}
+
+	styles := slices.DeleteFunc(parseSGRStyles(strings.TrimSpace(value)), func(style Style) bool {
+		return style == Reset
+	})
+
+	return NewStyle(styles...)
+}
+
+// Method to parse the value of the LS_COLORS environment variable, e.g. "di=01;34:ln=01;36:*.tar=01;31".
+// Malformed entries are skipped
+func ParseLSColors(value string) *LSColors {
+	colors := &LSColors{types: map[string]*Chalk{}}
+	for _, entry := range strings.Split(value, ":") {
+		key, style, found := strings.Cut(entry, "=")
+		if found && key != "" {
+			colors.set(key, style)
+		}
+	}
+
+	return colors
+}
+
+// Method to parse a dircolors database, as written by "dircolors --print-database".
+// TERM and COLOR sections are ignored, so entries for all terminals are read
+func ParseDircolors(reader io.Reader) (*LSColors, error) {
+	colors := &LSColors{types: map[string]*Chalk{}}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		key := fields[0]
+		switch {
+		case strings.HasPrefix(key, "."):
+			key = "*" + key
+		case strings.HasPrefix(key, "*"):
+		default:
+			var known bool
+			if key, known = dircolorsKeywords[strings.ToUpper(key)]; !known {
+				continue
+			}
+		}
+		colors.set(key, fields[1])
+	}
+
+	return colors, scanner.Err()
+}
+
+// Method to get the colors from the LS_COLORS environment variable, or the defaults of GNU ls if it isn't set
+//
+//	colors := gochalk.LSColorsFromEnv()
+//	for _, entry := range entries {
+//		fmt.Println(colors.Format(filepath.Join(dir, entry.Name())))
+//	}
+func LSColorsFromEnv() *LSColors {
+	if value := os.Getenv("LS_COLORS"); value != "" {
+		return ParseLSColors(value)
+	}
+
+	return ParseLSColors(defaultLSColors)
+}
+
+// Method to set the style of a file type key or "*suffix" pattern
+func (colors *LSColors) set(key string, style string) {
+	if suffix, isPattern := strings.CutPrefix(key, "*"); isPattern {
+		colors.patterns = slices.DeleteFunc(colors.patterns, func(pattern lsPattern) bool {
+			return strings.EqualFold(pattern.suffix, suffix)
+		})
+		colors.patterns = append(colors.patterns, lsPattern{suffix: suffix, chalk: ParseSGR(style)})
+		return
+	}
+
+	if key == "ln" && style == "target" {
+		colors.linkAsTarget = true
+		return
+	}
+	colors.types[key] = ParseSGR(style)
+}
+
+// Method to get the style for a file with the given name and mode, without accessing the file system.
+// Symlinks get the link style since their target is unknown
+func (colors *LSColors) StyleMode(name string, mode fs.FileMode) *Chalk {
+	var key string
+	switch {
+	case mode.IsDir() && mode&fs.ModeSticky != 0 && mode&0o002 != 0:
+		key = "tw"
+	case mode.IsDir() && mode&0o002 != 0:
+		key = "ow"
+	case mode.IsDir() && mode&fs.ModeSticky != 0:
+		key = "st"
+	case mode.IsDir():
+		key = "di"
+	case mode&fs.ModeSymlink != 0:
+		key = "ln"
+	case mode&fs.ModeNamedPipe != 0:
+		key = "pi"
+	case mode&fs.ModeSocket != 0:
+		key = "so"
+	case mode&fs.ModeCharDevice != 0:
+		key = "cd"
+	case mode&fs.ModeDevice != 0:
+		key = "bd"
+	case mode&fs.ModeSetuid != 0:
+		key = "su"
+	case mode&fs.ModeSetgid != 0:
+		key = "sg"
+	case mode&0o111 != 0:
+		key = "ex"
+	}
+
+	// Special files fall back to the normal file style when their key isn't set, like ls does
+	if chalk := colors.types[key]; chalk != nil {
+		return chalk
+	}
+	if key == "" || key == "ex" || key == "su" || key == "sg" {
+		if chalk := colors.patternStyle(name); chalk != nil {
+			return chalk
+		}
+	}
+	if chalk := colors.types["fi"]; chalk != nil {
+		return chalk
+	}
+	if chalk := colors.types["no"]; chalk != nil {
+		return chalk
+	}
+
+	return NewStyle()
+}
+
+// Method to get the style for a file, reading its mode from the file system. Symlinks pointing to missing files get
+// the orphan style, and the style of their target when LS_COLORS contains "ln=target".
+// Files that can't be read get the missing file style
+func (colors *LSColors) Style(path string) *Chalk {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if chalk := colors.types["mi"]; chalk != nil && len(chalk.styles) > 0 {
+			return chalk
+		}
+		return colors.StyleMode(path, 0)
+	}
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return colors.StyleMode(path, info.Mode())
+	}
+
+	target, err := os.Stat(path)
+	if err != nil {
+		if chalk := colors.types["or"]; chalk != nil && len(chalk.styles) > 0 {
+			return chalk
+		}
+		return colors.StyleMode(path, info.Mode())
+	}
+	if colors.linkAsTarget {
+		return colors.StyleMode(path, target.Mode())
+	}
+
+	return colors.StyleMode(path, info.Mode())
+}
+
+// Method to get path styled the way ls --color shows it
+func (colors *LSColors) Format(path string) string {
+	return colors.Style(path).ToString(path)
+}
+
+// Method to get the style of the first pattern matching the end of name. Matching ignores case
+func (colors *LSColors) patternStyle(name string) *Chalk {
+	base := strings.ToLower(filepath.Base(name))
+	for _, pattern := range colors.patterns {
+		if strings.HasSuffix(base, strings.ToLower(pattern.suffix)) {
+			return pattern.chalk
+		}
+	}
+
+	return nil
+}
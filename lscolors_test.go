@@ -0,0 +1,125 @@
+package gochalk
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSGR(t *testing.T) {
+	tests := map[string]string{
+		"01;31":          "\x1b[1;31mtext\x1b[0m",
+		"38;5;208;1":     "\x1b[1;38;5;208mtext\x1b[0m",
+		"0":              "text",
+		"":               "text",
+		"4;x;48;2;1;2;3": "\x1b[4;48;2;1;2;3mtext\x1b[0m",
+	}
+
+	for value, expected := range tests {
+		actual := ParseSGR(value).ToString("text")
+		if strings.Compare(actual, expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q (%q)", expected, actual, value)
+		}
+	}
+}
+
+func TestLSColors_StyleMode(t *testing.T) {
+	colors := ParseLSColors("di=01;34:ln=01;36:ex=01;32:tw=30;42:pi=40;33:*.tar=01;31:*.TAR=00;35:*README=33:fi=0:bogus")
+
+	tests := []struct {
+		name     string
+		mode     fs.FileMode
+		expected string
+	}{
+		{"src", fs.ModeDir | 0o755, "\x1b[1;34msrc\x1b[0m"},
+		{"tmp", fs.ModeDir | fs.ModeSticky | 0o777, "\x1b[30;42mtmp\x1b[0m"},
+		{"link", fs.ModeSymlink | 0o777, "\x1b[1;36mlink\x1b[0m"},
+		{"run.sh", 0o755, "\x1b[1;32mrun.sh\x1b[0m"},
+		{"backup.tar", 0o644, "\x1b[35mbackup.tar\x1b[0m"},
+		{"docs/README", 0o644, "\x1b[33mdocs/README\x1b[0m"},
+		{"fifo", fs.ModeNamedPipe | 0o644, "\x1b[33;40mfifo\x1b[0m"},
+		{"socket", fs.ModeSocket | 0o644, "socket"},
+		{"main.go", 0o644, "main.go"},
+	}
+
+	for _, test := range tests {
+		actual := colors.StyleMode(test.name, test.mode).ToString(test.name)
+		if strings.Compare(actual, test.expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", test.expected, actual)
+		}
+	}
+}
+
+func TestLSColors_Style(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	script := filepath.Join(dir, "build.sh")
+	link := filepath.Join(dir, "link")
+	broken := filepath.Join(dir, "broken")
+	os.WriteFile(file, nil, 0o644)
+	os.WriteFile(script, nil, 0o755)
+	if os.Symlink(script, link) != nil || os.Symlink(filepath.Join(dir, "missing"), broken) != nil {
+		t.Skip("Symlinks not supported")
+	}
+
+	colors := ParseLSColors("di=01;34:ln=01;36:or=40;31;01:ex=01;32:*.txt=33")
+	tests := map[string]*Chalk{
+		dir:    NewStyle(Bold, FgBlue),
+		file:   NewStyle(FgYellow),
+		script: NewStyle(Bold, FgGreen),
+		link:   NewStyle(Bold, FgCyan),
+		broken: NewStyle(Bold, FgRed, BgBlack),
+	}
+	for path, expected := range tests {
+		actualString := colors.Format(path)
+		expectedString := expected.ToString(path)
+		if strings.Compare(actualString, expectedString) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+		}
+	}
+
+	colors = ParseLSColors("ln=target:ex=01;32")
+	actualString := colors.Format(link)
+	expectedString := NewStyle(Bold, FgGreen).ToString(link)
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestParseDircolors(t *testing.T) {
+	database := `# Configuration file for dircolors
+COLOR tty
+TERM xterm*
+NORMAL 00
+DIR 01;34 # directory
+LINK 01;36
+EXEC 01;32
+
+.tar 01;31
+*.jpg 01;35
+UNKNOWN 01
+`
+	colors, err := ParseDircolors(strings.NewReader(database))
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		mode     fs.FileMode
+		expected string
+	}{
+		{"src", fs.ModeDir, "\x1b[1;34msrc\x1b[0m"},
+		{"a.tar", 0o644, "\x1b[1;31ma.tar\x1b[0m"},
+		{"b.JPG", 0o644, "\x1b[1;35mb.JPG\x1b[0m"},
+		{"c.txt", 0o644, "c.txt"},
+	}
+	for _, test := range tests {
+		actual := colors.StyleMode(test.name, test.mode).ToString(test.name)
+		if strings.Compare(actual, test.expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,629 @@
+package gochalk
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Options for rendering Markdown
+type MarkdownOptions struct {
+	// Width text is wrapped to. Defaults to the terminal width, a negative value disables wrapping
+	Width int
+	// Styles of elements by name, see DefaultMarkdownTheme. Fenced code blocks use the syntax classes of the theme.
+	// Elements missing from the theme are written plain. Defaults to DefaultMarkdownTheme
+	Theme Theme
+	// Color level of the output, detected for stdout by default. With ColorLevelNone, only layout, bullets and
+	// borders mark elements
+	Level ColorLevel
+}
+
+var (
+	markdownFence     = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})\\s*([^\\s`]*)")
+	markdownHeading   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	markdownRule      = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	markdownQuote     = regexp.MustCompile(`^ {0,3}> ?`)
+	markdownListItem  = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])(?: +|$)`)
+	markdownSetext    = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	markdownDelimiter = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+	markdownAutolink  = regexp.MustCompile(`^<((?:https?|ftp)://[^\s<>]+|mailto:[^\s<>]+)>`)
+)
+
+// Bullets of unordered lists, by nesting depth
+var markdownBullets = []string{"•", "◦", "▪"}
+
+// Characters that can be escaped with a backslash
+const markdownEscapable = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// List item with its number in an ordered list and its lines, without the marker and indentation
+type markdownItem struct {
+	number int
+	lines  []string
+}
+
+// State of a Markdown document being rendered
+type markdownRenderer struct {
+	options *MarkdownOptions
+}
+
+// Method to render Markdown for the terminal. Supports headings, emphasis, strong text, strikethrough, inline code,
+// fenced code blocks highlighted by the lexer registered for their language, ordered, unordered and task lists,
+// block quotes, horizontal rules, tables and links, which become OSC 8 hyperlinks when the terminal supports them.
+// Paragraphs, list items and quotes are wrapped to the width. Passing nil options uses DefaultMarkdownTheme
+//
+//	fmt.Println(gochalk.RenderMarkdown(changelog, nil))
+func RenderMarkdown(source string, options *MarkdownOptions) string {
+	renderer := &markdownRenderer{options: options.withDefaults()}
+	source = strings.ReplaceAll(strings.ReplaceAll(source, "\r\n", "\n"), "\t", "    ")
+
+	return strings.Join(renderer.blocks(strings.Split(source, "\n"), renderer.options.Width, 0, false), "\n")
+}
+
+// Method to get the default Markdown theme. It contains DefaultSyntaxTheme for code blocks and the styles of
+// "heading1", "heading2", "heading" (levels 3 to 6), "strong", "emphasis", "strikethrough", "code", "codeblock"
+// (code without a lexer), "link", "quote", "bullet", "rule", "tableborder" and "tableheader".
+// Each call returns a new Theme, so it can be changed freely
+func DefaultMarkdownTheme() Theme {
+	theme := DefaultSyntaxTheme()
+	elements := Theme{
+		"heading1":      NewStyle(FgMagenta, Bold, Underlined),
+		"heading2":      NewStyle(FgMagenta, Bold),
+		"heading":       NewStyle(Bold),
+		"strong":        NewStyle(Bold),
+		"emphasis":      NewStyle(Italics),
+		"strikethrough": NewStyle(Strikethrough),
+		"code":          NewStyle(FgCyan),
+		"codeblock":     NewStyle(FgCyan),
+		"link":          NewStyle(FgBlue, Underlined),
+		"quote":         NewStyle(Italics),
+		"bullet":        NewStyle(FgYellow),
+		"rule":          NewStyle(FgBrightBlack),
+		"tableborder":   NewStyle(FgBrightBlack),
+		"tableheader":   NewStyle(Bold),
+	}
+	for name, chalk := range elements {
+		theme[name] = chalk
+	}
+
+	return theme
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *MarkdownOptions) withDefaults() *MarkdownOptions {
+	result := MarkdownOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Width == 0 {
+		result.Width = TerminalWidth()
+	}
+	if result.Theme == nil {
+		result.Theme = DefaultMarkdownTheme()
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	return &result
+}
+
+// Method to style text with an element of the theme, or return it unchanged when colors are off
+func (renderer *markdownRenderer) paint(element string, text string) string {
+	return paintStyle(renderer.options.Level, renderer.options.Theme[element], text)
+}
+
+// Method to wrap text to width. Widths of 0 and less disable wrapping
+func (renderer *markdownRenderer) wrap(text string, width int) []string {
+	if renderer.options.Width > 0 {
+		text = Wrap(text, max(width, 10))
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// Method to render the blocks of lines nested depth lists deep. Blocks are separated by an empty line unless tight
+func (renderer *markdownRenderer) blocks(lines []string, width int, depth int, tight bool) []string {
+	var output []string
+	emit := func(block []string) {
+		if len(output) > 0 && !tight {
+			output = append(output, "")
+		}
+		output = append(output, block...)
+	}
+
+	for index := 0; index < len(lines); {
+		line := lines[index]
+		switch {
+		case strings.TrimSpace(line) == "":
+			index++
+		case markdownFence.MatchString(line):
+			var block []string
+			block, index = renderer.codeBlock(lines, index)
+			emit(block)
+		case markdownHeading.MatchString(line):
+			match := markdownHeading.FindStringSubmatch(line)
+			emit(renderer.heading(len(match[1]), match[2], width))
+			index++
+		case markdownRule.MatchString(line):
+			emit([]string{renderer.paint("rule", strings.Repeat("─", max(width, 3)))})
+			index++
+		case markdownQuote.MatchString(line):
+			var block []string
+			block, index = renderer.quote(lines, index, width, depth)
+			emit(block)
+		case markdownListItem.MatchString(line):
+			var block []string
+			block, index = renderer.list(lines, index, width, depth)
+			emit(block)
+		case index+1 < len(lines) && strings.Contains(line, "|") && markdownDelimiter.MatchString(lines[index+1]) &&
+			strings.Contains(lines[index+1], "-"):
+			var block []string
+			block, index = renderer.table(lines, index, width)
+			emit(block)
+		default:
+			var block []string
+			block, index = renderer.paragraph(lines, index, width)
+			emit(block)
+		}
+	}
+
+	return output
+}
+
+// Method to check if a line starts a block other than a paragraph, ending the paragraph before it
+func (renderer *markdownRenderer) interruptsParagraph(line string) bool {
+	return strings.TrimSpace(line) == "" || markdownFence.MatchString(line) || markdownHeading.MatchString(line) ||
+		markdownRule.MatchString(line) || markdownQuote.MatchString(line) || markdownListItem.MatchString(line)
+}
+
+// Method to render a paragraph starting at index. Lines ending in two spaces or a backslash break the line.
+// A paragraph followed by a line of = or - is a heading. Returns the lines and the index after the paragraph
+func (renderer *markdownRenderer) paragraph(lines []string, index int, width int) ([]string, int) {
+	var text strings.Builder
+	for first := true; index < len(lines); index++ {
+		line := lines[index]
+		if !first && markdownSetext.MatchString(line) {
+			level := 2
+			if strings.Contains(line, "=") {
+				level = 1
+			}
+			return renderer.heading(level, text.String(), width), index + 1
+		}
+		if !first && renderer.interruptsParagraph(line) {
+			break
+		}
+
+		if !first {
+			previous := lines[index-1]
+			if strings.HasSuffix(previous, "  ") || strings.HasSuffix(previous, "\\") {
+				text.WriteString("\n")
+			} else {
+				text.WriteString(" ")
+			}
+		}
+		text.WriteString(strings.TrimSuffix(strings.TrimSpace(line), "\\"))
+		first = false
+	}
+
+	return renderer.wrap(renderer.inline(text.String()), width), index
+}
+
+// Method to render a heading of level 1 to 6
+func (renderer *markdownRenderer) heading(level int, text string, width int) []string {
+	element := "heading"
+	if level <= 2 {
+		element += strconv.Itoa(level)
+	}
+
+	return renderer.wrap(renderer.paint(element, renderer.inline(strings.TrimSpace(text))), width)
+}
+
+// Method to render a fenced code block starting at index, highlighting it when a lexer is registered for its
+// language. Code is indented by two spaces and isn't wrapped. Returns the lines and the index after the block
+func (renderer *markdownRenderer) codeBlock(lines []string, index int) ([]string, int) {
+	match := markdownFence.FindStringSubmatch(lines[index])
+	indent, fence, language := len(match[1]), match[2], match[3]
+
+	var code []string
+	for index++; index < len(lines); index++ {
+		line := lines[index]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			index++
+			break
+		}
+		// Remove the indentation of the opening fence
+		spaces := len(line) - len(strings.TrimLeft(line, " "))
+		code = append(code, line[min(spaces, indent):])
+	}
+
+	source := strings.Join(code, "\n")
+	if language != "" && LookupLexer(language) != nil {
+		source = Highlight(source, language, &HighlightOptions{Theme: renderer.options.Theme, Level: renderer.options.Level})
+		code = strings.Split(source, "\n")
+	} else {
+		for line := range code {
+			code[line] = renderer.paint("codeblock", code[line])
+		}
+	}
+
+	for line := range code {
+		if code[line] != "" {
+			code[line] = "  " + code[line]
+		}
+	}
+
+	return code, index
+}
+
+// Method to render a block quote starting at index. Returns the lines and the index after the quote
+func (renderer *markdownRenderer) quote(lines []string, index int, width int, depth int) ([]string, int) {
+	var inner []string
+	for ; index < len(lines) && markdownQuote.MatchString(lines[index]); index++ {
+		inner = append(inner, markdownQuote.ReplaceAllString(lines[index], ""))
+	}
+
+	bar := renderer.paint("rule", "│")
+	block := renderer.blocks(inner, width-2, depth, false)
+	for line := range block {
+		if block[line] == "" {
+			block[line] = bar
+		} else {
+			block[line] = bar + " " + renderer.paint("quote", block[line])
+		}
+	}
+
+	return block, index
+}
+
+// Method to render a list starting at index. Items are rendered as blocks of their own, so they can contain
+// paragraphs, code and nested lists. Returns the lines and the index after the list
+func (renderer *markdownRenderer) list(lines []string, index int, width int, depth int) ([]string, int) {
+	first := markdownListItem.FindStringSubmatch(lines[index])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+	var items []markdownItem
+	loose := false
+	offset := 0
+
+	for index < len(lines) {
+		line := lines[index]
+		match := markdownListItem.FindStringSubmatch(line)
+		switch {
+		case match != nil && (len(items) == 0 || len(match[1]) < offset):
+			// A new item, unless it's a different kind of list
+			if (match[2][0] >= '0' && match[2][0] <= '9') != ordered {
+				return renderer.renderList(items, ordered, loose, width, depth), index
+			}
+			number, _ := strconv.Atoi(strings.TrimRight(match[2], ".)"))
+			offset = len(match[0])
+			if offset == len(line) {
+				// An item without text on its first line
+				offset = len(match[1]) + len(match[2]) + 1
+			}
+			items = append(items, markdownItem{number: number, lines: []string{line[min(len(match[0]), len(line)):]}})
+		case strings.TrimSpace(line) == "":
+			// Blank lines belong to the list if it continues after them
+			next := index + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next == len(lines) {
+				return renderer.renderList(items, ordered, loose, width, depth), next
+			}
+			indent := len(lines[next]) - len(strings.TrimLeft(lines[next], " "))
+			if indent < offset && !markdownListItem.MatchString(lines[next]) {
+				return renderer.renderList(items, ordered, loose, width, depth), index
+			}
+			if indent < offset {
+				loose = true
+			}
+			item := &items[len(items)-1]
+			for ; index < next; index++ {
+				item.lines = append(item.lines, "")
+			}
+			continue
+		default:
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+			previous := strings.TrimSpace(lines[index-1])
+			if indent < offset && (previous == "" || renderer.interruptsParagraph(line)) {
+				return renderer.renderList(items, ordered, loose, width, depth), index
+			}
+			// Continuation lines, including lazy continuations of a paragraph without indentation
+			items[len(items)-1].lines = append(items[len(items)-1].lines, line[min(indent, offset):])
+		}
+		index++
+	}
+
+	return renderer.renderList(items, ordered, loose, width, depth), index
+}
+
+// Method to render list items with their bullets or numbers
+func (renderer *markdownRenderer) renderList(items []markdownItem, ordered bool, loose bool, width int, depth int) []string {
+	markers := make([]string, len(items))
+	boxes := make([]string, len(items))
+	markerWidth := 0
+	for index, item := range items {
+		if ordered {
+			markers[index] = strconv.Itoa(items[0].number+index) + "."
+		} else {
+			markers[index] = markdownBullets[depth%len(markdownBullets)]
+		}
+		markerWidth = max(markerWidth, StringWidth(markers[index]))
+
+		// Task list items get a check box after their marker
+		if text := item.lines[0]; len(text) >= 3 && text[0] == '[' && text[2] == ']' && strings.ContainsRune(" xX", rune(text[1])) {
+			boxes[index] = "☐ "
+			if text[1] != ' ' {
+				boxes[index] = "☑ "
+			}
+			item.lines[0] = strings.TrimLeft(text[3:], " ")
+		}
+	}
+
+	var output []string
+	for index, item := range items {
+		if index > 0 && loose {
+			output = append(output, "")
+		}
+		indent := markerWidth + 1 + StringWidth(boxes[index])
+		block := renderer.blocks(item.lines, width-indent, depth+1, !loose)
+		if len(block) == 0 {
+			block = []string{""}
+		}
+		for line, text := range block {
+			switch {
+			case line == 0:
+				alignment := AlignLeft
+				if ordered {
+					alignment = AlignRight
+				}
+				marker := renderer.paint("bullet", alignString(markers[index], markerWidth, alignment)+" "+boxes[index])
+				block[line] = strings.TrimRight(marker+text, " ")
+			case text != "":
+				block[line] = strings.Repeat(" ", indent) + text
+			}
+		}
+		output = append(output, block...)
+	}
+
+	return output
+}
+
+// Method to render a table starting at index, whose second line holds the column alignments.
+// Returns the lines and the index after the table
+func (renderer *markdownRenderer) table(lines []string, index int, width int) ([]string, int) {
+	table := &Table{Border: BorderRounded, MaxWidth: width}
+	if renderer.options.Width < 0 {
+		table.MaxWidth = -1
+	}
+	if renderer.options.Level != ColorLevelNone {
+		table.BorderStyle = renderer.options.Theme["tableborder"]
+		table.HeaderStyle = renderer.options.Theme["tableheader"]
+	}
+
+	for _, cell := range markdownTableCells(lines[index]) {
+		table.Headers = append(table.Headers, renderer.inline(cell))
+	}
+	for _, cell := range markdownTableCells(lines[index+1]) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			table.Alignments = append(table.Alignments, AlignCenter)
+		case strings.HasSuffix(cell, ":"):
+			table.Alignments = append(table.Alignments, AlignRight)
+		default:
+			table.Alignments = append(table.Alignments, AlignLeft)
+		}
+	}
+
+	for index += 2; index < len(lines) && strings.TrimSpace(lines[index]) != "" && strings.Contains(lines[index], "|"); index++ {
+		var row []string
+		for _, cell := range markdownTableCells(lines[index]) {
+			row = append(row, renderer.inline(cell))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return strings.Split(table.Render(), "\n"), index
+}
+
+// Method to split a table row into its cells. Pipes escaped with a backslash are kept in the cell
+func markdownTableCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for index := 0; index < len(line); index++ {
+		switch {
+		case line[index] == '\\' && index+1 < len(line) && line[index+1] == '|':
+			cell.WriteByte('|')
+			index++
+		case line[index] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[index])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// Method to render inline elements: code spans, emphasis, strong text, strikethrough, links, images, autolinks and
+// backslash escapes
+func (renderer *markdownRenderer) inline(text string) string {
+	var builder strings.Builder
+	for index := 0; index < len(text); {
+		char := text[index]
+		switch {
+		case char == '\\' && index+1 < len(text) && strings.IndexByte(markdownEscapable, text[index+1]) >= 0:
+			builder.WriteByte(text[index+1])
+			index += 2
+			continue
+		case char == '`':
+			run := markdownRun(text, index)
+			if end := strings.Index(text[index+run:], text[index:index+run]); end >= 0 {
+				code := text[index+run : index+run+end]
+				if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				builder.WriteString(renderer.paint("code", code))
+				index += 2*run + end
+				continue
+			}
+			builder.WriteString(text[index : index+run])
+			index += run
+			continue
+		case char == '[' || char == '!' && index+1 < len(text) && text[index+1] == '[':
+			start := index
+			if char == '!' {
+				start++
+			}
+			if label, url, end, ok := markdownLink(text, start); ok {
+				builder.WriteString(renderer.link(url, label))
+				index = end
+				continue
+			}
+		case char == '<':
+			if match := markdownAutolink.FindStringSubmatch(text[index:]); match != nil {
+				builder.WriteString(renderer.link(match[1], ""))
+				index += len(match[0])
+				continue
+			}
+		case char == '*' || char == '_' || char == '~':
+			if styled, end, ok := renderer.emphasis(text, index); ok {
+				builder.WriteString(styled)
+				index = end
+				continue
+			}
+			run := markdownRun(text, index)
+			builder.WriteString(text[index : index+run])
+			index += run
+			continue
+		}
+
+		builder.WriteByte(char)
+		index++
+	}
+
+	return builder.String()
+}
+
+// Method to render emphasis, strong text or strikethrough starting with the delimiter run at index.
+// Returns the styled text, the index after the closing delimiters and whether the run was opened and closed
+func (renderer *markdownRenderer) emphasis(text string, index int) (string, int, bool) {
+	char := text[index]
+	run := markdownRun(text, index)
+	if char == '~' && run != 2 || run > 3 {
+		return "", 0, false
+	}
+
+	// Openers must be followed by text, and underscores inside words don't count, as in snake_case
+	after := index + run
+	if after >= len(text) || text[after] == ' ' || text[after] == '\n' {
+		return "", 0, false
+	}
+	if char == '_' && index > 0 && isMarkdownWordByte(text[index-1]) {
+		return "", 0, false
+	}
+
+	for closing := after + 1; closing < len(text); {
+		if text[closing] != char {
+			closing++
+			continue
+		}
+		length := markdownRun(text, closing)
+		previous := text[closing-1]
+		next := closing + length
+		if length == run && previous != ' ' && previous != '\n' && (char != '_' || next >= len(text) || !isMarkdownWordByte(text[next])) {
+			inner := renderer.inline(text[after:closing])
+			switch {
+			case char == '~':
+				inner = renderer.paint("strikethrough", inner)
+			case run == 1:
+				inner = renderer.paint("emphasis", inner)
+			case run == 2:
+				inner = renderer.paint("strong", inner)
+			default:
+				inner = renderer.paint("strong", renderer.paint("emphasis", inner))
+			}
+			return inner, next, true
+		}
+		closing = next
+	}
+
+	return "", 0, false
+}
+
+// Method to render a link. Without hyperlink support or colors the url follows the text in parentheses.
+// An empty label shows the url
+func (renderer *markdownRenderer) link(url string, label string) string {
+	url = Sanitize(url, SanitizeStrip)
+	text := label
+	if text == "" {
+		text = url
+	}
+
+	styled := renderer.paint("link", renderer.inline(text))
+	if renderer.options.Level == ColorLevelNone || !SupportsHyperlinks() {
+		if text == url || "mailto:"+text == url {
+			return styled
+		}
+		return fmt.Sprintf("%s (%s)", styled, url)
+	}
+
+	return hyperlinkStart("", url) + styled + hyperlinkEnd()
+}
+
+// Method to parse a link "[label](url)" or "[label](url "title")" at index. Returns the label, the url, the index
+// after the link and whether there was a link
+func markdownLink(text string, index int) (string, string, int, bool) {
+	depth := 0
+	for closing := index; closing < len(text); closing++ {
+		switch text[closing] {
+		case '\\':
+			closing++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth > 0 {
+				continue
+			}
+			if closing+1 >= len(text) || text[closing+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(text[closing+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			destination := strings.TrimSpace(text[closing+2 : closing+2+end])
+			url, _, _ := strings.Cut(destination, " ")
+			url = strings.TrimSuffix(strings.TrimPrefix(url, "<"), ">")
+			return text[index+1 : closing], url, closing + 3 + end, true
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// Method to get the length of the run of the character at index
+func markdownRun(text string, index int) int {
+	end := index
+	for end < len(text) && text[end] == text[index] {
+		end++
+	}
+
+	return end - index
+}
+
+// Method to check if a byte is part of a word, for intraword underscores. Bytes of multibyte characters count as
+// word characters
+func isMarkdownWordByte(char byte) bool {
+	return char >= utf8.RuneSelf || unicode.IsLetter(rune(char)) || unicode.IsDigit(rune(char))
+}
@@ -0,0 +1,90 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	setTestHyperlinkSupport(t, false)
+
+	source := "# Release 1.2\n\n" +
+		"Some **bold**, *em*, `code` and ~~gone~~ text with a [link](https://go.dev) and snake_case_name.\n\n" +
+		"## Changes\n\n" +
+		"- First item\n  continued\n- Second with nested:\n  1. one\n  2. two\n- [x] done task\n\n" +
+		"> Quoted\n> text\n\n" +
+		"```sh\necho hi\n```\n\n" +
+		"| Name | Count |\n|:-----|------:|\n| a \\| b | 1 |\n\n" +
+		"***\n" +
+		"Setext\n------\n" +
+		"<https://example.com>"
+
+	actualString := RenderMarkdown(source, &MarkdownOptions{Width: 40, Level: ColorLevelNone})
+	expectedString := `Release 1.2
+
+Some bold, em, code and gone text with a
+link (https://go.dev) and
+snake_case_name.
+
+Changes
+
+• First item continued
+• Second with nested:
+  1. one
+  2. two
+• ☑ done task
+
+│ Quoted text
+
+  echo hi
+
+╭───────┬───────╮
+│ Name  │ Count │
+├───────┼───────┤
+│ a | b │     1 │
+╰───────┴───────╯
+
+────────────────────────────────────────
+
+Setext
+
+https://example.com`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestRenderMarkdown_LooseList(t *testing.T) {
+	actualString := RenderMarkdown("9. first\n\n   more\n\n10. second\n", &MarkdownOptions{Width: -1, Level: ColorLevelNone})
+	expectedString := " 9. first\n\n    more\n\n10. second"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestRenderMarkdown_Styles(t *testing.T) {
+	setTestHyperlinkSupport(t, true)
+
+	actualString := RenderMarkdown("**a *b* c** [Go](https://go.dev) `x`", &MarkdownOptions{Width: -1, Level: ColorLevel16})
+	expectedString := overlayStyle(NewStyle(Bold), "a "+NewStyle(Italics).ToString("b")+" c") + " " +
+		hyperlinkStart("", "https://go.dev") + NewStyle(FgBlue, Underlined).ToString("Go") + hyperlinkEnd() + " " +
+		NewStyle(FgCyan).ToString("x")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString = RenderMarkdown("```go\nreturn nil\n```\n## Title", &MarkdownOptions{Width: -1, Level: ColorLevel16})
+	expectedString = "  " + NewStyle(FgMagenta).ToString("return") + " " + NewStyle(FgYellow).ToString("nil") + "\n\n" +
+		NewStyle(FgMagenta, Bold).ToString("Title")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestRenderMarkdown_Escapes(t *testing.T) {
+	actualString := RenderMarkdown(`\*not em\* and 2 * 3 * 4 and a_b_c`, &MarkdownOptions{Width: -1, Level: ColorLevelNone})
+	expectedString := "*not em* and 2 * 3 * 4 and a_b_c"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
@@ -0,0 +1,222 @@
+package gochalk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Built-in palettes of common terminals and color schemes
+var (
+	// Default colors of xterm. Same as DefaultPalette
+	PaletteXterm = DefaultPalette
+	// Colors of the VGA text mode, used by the Linux console
+	PaletteVGA = Palette{
+		{0x00, 0x00, 0x00}, {0xaa, 0x00, 0x00}, {0x00, 0xaa, 0x00}, {0xaa, 0x55, 0x00},
+		{0x00, 0x00, 0xaa}, {0xaa, 0x00, 0xaa}, {0x00, 0xaa, 0xaa}, {0xaa, 0xaa, 0xaa},
+		{0x55, 0x55, 0x55}, {0xff, 0x55, 0x55}, {0x55, 0xff, 0x55}, {0xff, 0xff, 0x55},
+		{0x55, 0x55, 0xff}, {0xff, 0x55, 0xff}, {0x55, 0xff, 0xff}, {0xff, 0xff, 0xff},
+	}
+	// Solarized color scheme. Light and dark variants share the same palette
+	PaletteSolarized = Palette{
+		{0x07, 0x36, 0x42}, {0xdc, 0x32, 0x2f}, {0x85, 0x99, 0x00}, {0xb5, 0x89, 0x00},
+		{0x26, 0x8b, 0xd2}, {0xd3, 0x36, 0x82}, {0x2a, 0xa1, 0x98}, {0xee, 0xe8, 0xd5},
+		{0x00, 0x2b, 0x36}, {0xcb, 0x4b, 0x16}, {0x58, 0x6e, 0x75}, {0x65, 0x7b, 0x83},
+		{0x83, 0x94, 0x96}, {0x6c, 0x71, 0xc4}, {0x93, 0xa1, 0xa1}, {0xfd, 0xf6, 0xe3},
+	}
+	// Campbell scheme, the default of Windows Terminal
+	PaletteWindowsTerminal = Palette{
+		{0x0c, 0x0c, 0x0c}, {0xc5, 0x0f, 0x1f}, {0x13, 0xa1, 0x0e}, {0xc1, 0x9c, 0x00},
+		{0x00, 0x37, 0xda}, {0x88, 0x17, 0x98}, {0x3a, 0x96, 0xdd}, {0xcc, 0xcc, 0xcc},
+		{0x76, 0x76, 0x76}, {0xe7, 0x48, 0x56}, {0x16, 0xc6, 0x0c}, {0xf9, 0xf1, 0xa5},
+		{0x3b, 0x78, 0xff}, {0xb4, 0x00, 0x9e}, {0x61, 0xd6, 0xd6}, {0xf2, 0xf2, 0xf2},
+	}
+	// Default profile of macOS Terminal.app
+	PaletteMacOSTerminal = Palette{
+		{0x00, 0x00, 0x00}, {0x99, 0x00, 0x00}, {0x00, 0xa6, 0x00}, {0x99, 0x99, 0x00},
+		{0x00, 0x00, 0xb2}, {0xb2, 0x00, 0xb2}, {0x00, 0xa6, 0xb2}, {0xbf, 0xbf, 0xbf},
+		{0x66, 0x66, 0x66}, {0xe5, 0x00, 0x00}, {0x00, 0xd9, 0x00}, {0xe5, 0xe5, 0x00},
+		{0x00, 0x00, 0xff}, {0xe5, 0x00, 0xe5}, {0x00, 0xe5, 0xe5}, {0xe5, 0xe5, 0xe5},
+	}
+)
+
+var (
+	paletteMutex   sync.RWMutex
+	currentPalette = DefaultPalette
+)
+
+// Limit on the size of terminal replies read by QueryPalette
+const maxPaletteReply = 64 * 1024
+
+// Time QueryPalette waits for replies when no timeout is given
+const defaultPaletteTimeout = time.Second
+
+// Method to set the palette of the terminal. It is used to pick the closest basic color when downsampling,
+// to check contrast and to simulate color blindness. Defaults to DefaultPalette
+//
+//	gochalk.SetPalette(gochalk.PaletteSolarized)
+func SetPalette(palette Palette) {
+	paletteMutex.Lock()
+	defer paletteMutex.Unlock()
+
+	currentPalette = palette
+}
+
+// Method to get the palette set using SetPalette
+func CurrentPalette() Palette {
+	paletteMutex.RLock()
+	defer paletteMutex.RUnlock()
+
+	return currentPalette
+}
+
+// Method to ask a terminal for the real colors of its palette using OSC 4. A device attributes request is sent
+// after the queries, which every terminal answers, so terminals ignoring OSC 4 don't block forever.
+// Colors the terminal didn't report are taken from DefaultPalette.
+//
+// The terminal has to be in raw mode while querying, so the replies aren't echoed and can be read before a newline.
+// An error is returned when no reply arrives within timeout, e.g. because the terminal isn't in raw mode.
+// Non-positive timeouts wait one second. Files which aren't terminals are refused without writing the queries.
+// Readers other than files, which can't be interrupted, keep being read in the background after a timeout
+//
+//	palette, err := gochalk.QueryPalette(tty, 200*time.Millisecond) // tty opened from /dev/tty and set to raw mode
+//	if err == nil {
+//		gochalk.SetPalette(palette)
+//	}
+func QueryPalette(terminal io.ReadWriter, timeout time.Duration) (Palette, error) {
+	if timeout <= 0 {
+		timeout = defaultPaletteTimeout
+	}
+	deadline := false
+	if file, ok := terminal.(*os.File); ok {
+		if !isTerminal(file) {
+			return DefaultPalette, errors.New("gochalk: querying palette: not a terminal")
+		}
+		if file.SetReadDeadline(time.Now().Add(timeout)) == nil {
+			deadline = true
+			defer file.SetReadDeadline(time.Time{})
+		}
+	}
+
+	var query strings.Builder
+	for index := 0; index < len(Palette{}); index++ {
+		fmt.Fprintf(&query, "%s]4;%d;?%s\\", escape, index, escape)
+	}
+	query.WriteString(escape + "[c")
+	if _, err := io.WriteString(terminal, query.String()); err != nil {
+		return DefaultPalette, err
+	}
+
+	var reply string
+	var err error
+	if deadline {
+		reply, err = readPaletteReply(terminal)
+	} else {
+		reply, err = readPaletteReplyWithin(terminal, timeout)
+	}
+	if err != nil {
+		return DefaultPalette, err
+	}
+
+	palette, found := parsePaletteReply(reply)
+	if found == 0 {
+		return DefaultPalette, errors.New("gochalk: terminal did not report its palette")
+	}
+
+	return palette, nil
+}
+
+// Method to read replies from the terminal until the answer to the device attributes request arrives
+func readPaletteReply(terminal io.Reader) (string, error) {
+	var reply []byte
+	chunk := make([]byte, 256)
+	for {
+		count, err := terminal.Read(chunk)
+		reply = append(reply, chunk[:count]...)
+		for _, token := range Tokenize(string(reply)) {
+			if token.Kind == TokenCSI && token.Final == 'c' && strings.HasPrefix(token.Params, "?") {
+				return string(reply), nil
+			}
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("gochalk: reading palette: %w", err)
+		}
+		if len(reply) > maxPaletteReply {
+			return "", errors.New("gochalk: reading palette: reply too long")
+		}
+	}
+}
+
+// Method to read replies from the terminal, giving up after timeout
+func readPaletteReplyWithin(terminal io.Reader, timeout time.Duration) (string, error) {
+	type result struct {
+		reply string
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		reply, err := readPaletteReply(terminal)
+		results <- result{reply, err}
+	}()
+
+	select {
+	case result := <-results:
+		return result.reply, result.err
+	case <-time.After(timeout):
+		return "", errors.New("gochalk: reading palette: timed out")
+	}
+}
+
+// Method to read the colors from OSC 4 replies. Returns the palette and number of colors found
+func parsePaletteReply(reply string) (Palette, int) {
+	palette := DefaultPalette
+	found := 0
+	for _, token := range Tokenize(reply) {
+		if token.Kind != TokenOSC {
+			continue
+		}
+
+		fields := strings.SplitN(token.Params, ";", 3)
+		if len(fields) != 3 || fields[0] != "4" {
+			continue
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil || index < 0 || index >= len(palette) {
+			continue
+		}
+		if color, ok := parseXColor(fields[2]); ok {
+			palette[index] = color
+			found++
+		}
+	}
+
+	return palette, found
+}
+
+// Method to parse a color in the "rgb:rrrr/gggg/bbbb" format used by X11 and terminal replies.
+// Each channel has 1 to 4 hex digits
+func parseXColor(value string) (Color, bool) {
+	channels, found := strings.CutPrefix(value, "rgb:")
+	parts := strings.Split(channels, "/")
+	if !found || len(parts) != 3 {
+		return Color{}, false
+	}
+
+	var rgb [3]uint8
+	for index, part := range parts {
+		parsed, err := strconv.ParseUint(part, 16, 16)
+		if err != nil || len(part) == 0 || len(part) > 4 {
+			return Color{}, false
+		}
+		maximum := uint64(1)<<(4*len(part)) - 1
+		rgb[index] = uint8((parsed*255 + maximum/2) / maximum)
+	}
+
+	return Color{rgb[0], rgb[1], rgb[2]}, true
+}
@@ -0,0 +1,143 @@
+package gochalk
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Terminal answering queries with a fixed reply, returned a few bytes at a time
+type fakeTerminal struct {
+	written bytes.Buffer
+	reply   string
+}
+
+func (terminal *fakeTerminal) Write(data []byte) (int, error) {
+	return terminal.written.Write(data)
+}
+
+func (terminal *fakeTerminal) Read(data []byte) (int, error) {
+	if terminal.reply == "" {
+		return 0, io.EOF
+	}
+	count := copy(data[:min(len(data), 7)], terminal.reply)
+	terminal.reply = terminal.reply[count:]
+	return count, nil
+}
+
+func TestSetPalette(t *testing.T) {
+	SetPalette(PaletteSolarized)
+	defer SetPalette(DefaultPalette)
+
+	actualString := Gradient("a", []Color{{0xcb, 0x4b, 0x16}}, &GradientOptions{Level: ColorLevel16})
+	expectedString := escapedStyle(FgBrightRed) + "a" + resetStyle
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actual := NewStyle(FgWhite).Contrast()
+	expected := ContrastRatio(PaletteSolarized[7], PaletteSolarized[0])
+	if actual != expected {
+		t.Errorf("\nExpected: %.2f\nActual: %.2f", expected, actual)
+	}
+
+	actualString = ANSIToHTML(Red("a"), nil)
+	if expectedString := `<span style="color:#dc322f">a</span>`; strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+	if actualString := RenderSVG(Red("a"), nil); !strings.Contains(actualString, `fill="#dc322f"`) {
+		t.Errorf("Expected SVG to use the palette\nActual: %q", actualString)
+	}
+
+	SetPalette(DefaultPalette)
+	if CurrentPalette() != PaletteXterm {
+		t.Errorf("\nExpected: %v\nActual: %v", PaletteXterm, CurrentPalette())
+	}
+}
+
+func TestQueryPalette(t *testing.T) {
+	terminal := &fakeTerminal{reply: "\x1b]4;0;rgb:2828/2a2a/3636\x1b\\\x1b]4;1;rgb:f/5/5\x07" +
+		"\x1b]4;15;rgb:ffff/ffff/ffff\x1b\\\x1b]4;2;bogus\x07\x1b[?62;22c"}
+
+	palette, err := QueryPalette(terminal, time.Second)
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nActual: %v", err)
+	}
+
+	expected := DefaultPalette
+	expected[0] = Color{0x28, 0x2a, 0x36}
+	expected[1] = Color{0xff, 0x55, 0x55}
+	expected[15] = Color{0xff, 0xff, 0xff}
+	if palette != expected {
+		t.Errorf("\nExpected: %v\nActual: %v", expected, palette)
+	}
+
+	written := terminal.written.String()
+	if !strings.HasPrefix(written, "\x1b]4;0;?\x1b\\\x1b]4;1;?\x1b\\") || !strings.HasSuffix(written, "\x1b]4;15;?\x1b\\\x1b[c") {
+		t.Errorf("\nExpected: OSC 4 queries followed by device attributes request\nActual: %q", written)
+	}
+}
+
+func TestQueryPalette_Unsupported(t *testing.T) {
+	palette, err := QueryPalette(&fakeTerminal{reply: "\x1b[?1;2c"}, time.Second)
+	if err == nil || palette != DefaultPalette {
+		t.Errorf("\nExpected: Error and default palette\nActual: %v", err)
+	}
+
+	_, err = QueryPalette(&fakeTerminal{reply: "\x1b]4;0;rgb:00/00/00\x07"}, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "EOF") {
+		t.Errorf("\nExpected: EOF error\nActual: %v", err)
+	}
+}
+
+func TestQueryPalette_NoReply(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	terminal := struct {
+		io.Reader
+		io.Writer
+	}{reader, io.Discard}
+	palette, err := QueryPalette(terminal, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out") || palette != DefaultPalette {
+		t.Errorf("\nExpected: Timeout error and default palette\nActual: %v", err)
+	}
+}
+
+func TestQueryPalette_NotTerminal(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	_, err = QueryPalette(writer, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "not a terminal") {
+		t.Errorf("\nExpected: Not a terminal error\nActual: %v", err)
+	}
+}
+
+func TestParseXColor(t *testing.T) {
+	tests := map[string]Color{
+		"rgb:ffff/0000/8080": {255, 0, 128},
+		"rgb:ff/00/80":       {255, 0, 128},
+		"rgb:f/0/8":          {255, 0, 136},
+		"rgb:fff/000/800":    {255, 0, 128},
+	}
+	for value, expected := range tests {
+		actual, ok := parseXColor(value)
+		if !ok || actual != expected {
+			t.Errorf("\nExpected: %v\nActual: %v (%s)", expected, actual, value)
+		}
+	}
+
+	for _, value := range []string{"", "rgb:ff/00", "#ff0000", "rgb:fffff/0/0", "rgb://"} {
+		if _, ok := parseXColor(value); ok {
+			t.Errorf("\nExpected: %q to be rejected\nActual: Accepted", value)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package gochalk
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source of time used by animated components. Replace it in tests to control time
+type Clock interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+}
+
+// Clock using the system time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+// Time constant used to smooth the rate of progress bars. Older updates lose weight over this period
+const progressRateSmoothing = 5 * time.Second
+
+// Characters, width and styles used to draw progress bars
+type ProgressStyle struct {
+	// Width of the bar in columns. The bar is left out when it is 0 or less, showing only the percentage
+	Width      int
+	FilledChar string
+	EmptyChar  string
+	Filled     *Chalk
+	Empty      *Chalk
+	Percentage *Chalk
+	Label      *Chalk
+	// Style for rate and ETA
+	Details *Chalk
+}
+
+// Method to get the default progress bar style: a green bar on a dim track with bold percentage
+func DefaultProgressStyle() ProgressStyle {
+	return ProgressStyle{
+		Width:      30,
+		FilledChar: "█",
+		EmptyChar:  "░",
+		Filled:     NewStyle(FgGreen),
+		Empty:      NewStyle(Dim),
+		Percentage: NewStyle(Bold),
+		Label:      NewStyle(),
+		Details:    NewStyle(FgBrightBlack),
+	}
+}
+
+// Group of progress bars drawn together on a writer. On a terminal the bars are redrawn in place,
+// otherwise a plain line per bar is printed periodically. All methods are safe to call from multiple goroutines.
+// Exported fields should be set before adding bars
+type Progress struct {
+	Style ProgressStyle
+	// Draw bars in place using escape sequences. Detected by NewProgress
+	Terminal bool
+	// Minimum time between redraws on a terminal
+	RefreshInterval time.Duration
+	// Time between plain lines when not on a terminal
+	PlainInterval time.Duration
+	Clock         Clock
+
+	mutex       sync.Mutex
+	writer      io.Writer
+	bars        []*ProgressBar
+	linesDrawn  int
+	lastRefresh time.Time
+	lastPlain   time.Time
+	stopped     bool
+}
+
+// A single progress bar in a Progress group
+type ProgressBar struct {
+	progress *Progress
+
+	mutex      sync.Mutex
+	label      string
+	total      int64
+	current    int64
+	started    time.Time
+	lastUpdate time.Time
+	rate       float64
+	finished   bool
+	// Progress value of the last plain line, to avoid printing the same line twice
+	plainPrinted bool
+	lastPlain    int64
+}
+
+// Creates a new Progress drawing to writer. Terminal mode is enabled when writer is a terminal
+//
+//	progress := gochalk.NewProgress(os.Stderr)
+//	bar := progress.AddBar("Uploading", int64(len(files)))
+//	for _, file := range files {
+//		upload(file)
+//		bar.Add(1)
+//	}
+//	progress.Stop()
+func NewProgress(writer io.Writer) *Progress {
+	file, _ := writer.(*os.File)
+
+	return &Progress{
+		Style:           DefaultProgressStyle(),
+		Terminal:        isTerminal(file),
+		RefreshInterval: 100 * time.Millisecond,
+		PlainInterval:   5 * time.Second,
+		Clock:           systemClock{},
+		writer:          writer,
+	}
+}
+
+// Method to add a new bar to the group. A total of 0 or less shows the count and rate without a bar
+func (progress *Progress) AddBar(label string, total int64) *ProgressBar {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	now := progress.Clock.Now()
+	bar := &ProgressBar{progress: progress, label: label, total: total, started: now, lastUpdate: now}
+	progress.bars = append(progress.bars, bar)
+
+	return bar
+}
+
+// Method to print a line above the bars without them getting mixed up
+func (progress *Progress) Println(value ...string) {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	var builder strings.Builder
+	if progress.Terminal && !progress.stopped {
+		builder.WriteString(progress.clearSequence())
+		progress.linesDrawn = 0
+	}
+	builder.WriteString(combineStrings(value...) + "\n")
+	io.WriteString(progress.writer, builder.String())
+
+	if progress.Terminal && !progress.stopped {
+		progress.draw()
+	}
+}
+
+// Method to draw the final state of all bars and stop refreshing
+func (progress *Progress) Stop() {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	if progress.stopped {
+		return
+	}
+	if progress.Terminal {
+		progress.draw()
+		if progress.linesDrawn > 0 {
+			io.WriteString(progress.writer, "\n")
+		}
+	} else {
+		for _, bar := range progress.bars {
+			if !bar.snapshot().finished {
+				progress.writePlain(bar)
+			}
+		}
+	}
+	progress.stopped = true
+}
+
+// Method to increase progress by count
+func (bar *ProgressBar) Add(count int64) {
+	bar.mutex.Lock()
+	bar.update(bar.current+count, bar.progress.Clock.Now())
+	bar.mutex.Unlock()
+
+	bar.progress.refresh(bar, false)
+}
+
+// Method to set the current progress
+func (bar *ProgressBar) Set(current int64) {
+	bar.mutex.Lock()
+	bar.update(current, bar.progress.Clock.Now())
+	bar.mutex.Unlock()
+
+	bar.progress.refresh(bar, false)
+}
+
+// Method to change the total. Useful when the amount of work is discovered while running
+func (bar *ProgressBar) SetTotal(total int64) {
+	bar.mutex.Lock()
+	bar.total = total
+	bar.mutex.Unlock()
+
+	bar.progress.refresh(bar, false)
+}
+
+// Method to change the label shown before the bar
+func (bar *ProgressBar) SetLabel(label string) {
+	bar.mutex.Lock()
+	bar.label = label
+	bar.mutex.Unlock()
+
+	bar.progress.refresh(bar, false)
+}
+
+// Method to mark the bar complete. Progress is set to the total if a total is known
+func (bar *ProgressBar) Finish() {
+	bar.mutex.Lock()
+	if bar.finished {
+		bar.mutex.Unlock()
+		return
+	}
+	if bar.total > 0 {
+		bar.update(bar.total, bar.progress.Clock.Now())
+	}
+	bar.finished = true
+	bar.mutex.Unlock()
+
+	bar.progress.refresh(bar, true)
+}
+
+// Method to set progress and update the smoothed rate. Caller must hold the bar mutex
+func (bar *ProgressBar) update(current int64, now time.Time) {
+	elapsed := now.Sub(bar.lastUpdate)
+	if elapsed > 0 {
+		instantRate := float64(current-bar.current) / elapsed.Seconds()
+		if bar.lastUpdate.Equal(bar.started) {
+			bar.rate = instantRate
+		} else {
+			weight := 1 - math.Exp(-elapsed.Seconds()/progressRateSmoothing.Seconds())
+			bar.rate = weight*instantRate + (1-weight)*bar.rate
+		}
+		bar.lastUpdate = now
+	}
+	bar.current = current
+}
+
+// Copy of the state of a bar, taken so rendering doesn't hold the bar mutex
+type progressSnapshot struct {
+	label    string
+	total    int64
+	current  int64
+	rate     float64
+	elapsed  time.Duration
+	finished bool
+}
+
+// Method to get a copy of the state of the bar
+func (bar *ProgressBar) snapshot() progressSnapshot {
+	bar.mutex.Lock()
+	defer bar.mutex.Unlock()
+
+	return progressSnapshot{
+		label:    bar.label,
+		total:    bar.total,
+		current:  bar.current,
+		rate:     bar.rate,
+		elapsed:  bar.lastUpdate.Sub(bar.started),
+		finished: bar.finished,
+	}
+}
+
+// Method to redraw bars after a change to bar. Redraws are throttled unless force is set
+func (progress *Progress) refresh(bar *ProgressBar, force bool) {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	if progress.stopped {
+		return
+	}
+
+	now := progress.Clock.Now()
+	if progress.Terminal {
+		if force || now.Sub(progress.lastRefresh) >= progress.RefreshInterval {
+			progress.draw()
+			progress.lastRefresh = now
+		}
+		return
+	}
+
+	if force {
+		progress.writePlain(bar)
+		return
+	}
+	if now.Sub(progress.lastPlain) >= progress.PlainInterval {
+		for _, each := range progress.bars {
+			if !each.snapshot().finished {
+				progress.writePlain(each)
+			}
+		}
+		progress.lastPlain = now
+	}
+}
+
+// Method to redraw all bars in place with a single write. Caller must hold the progress mutex
+func (progress *Progress) draw() {
+	lines := make([]string, len(progress.bars))
+	for index, bar := range progress.bars {
+		lines[index] = "\x1b[2K" + progress.renderBar(bar.snapshot())
+	}
+
+	io.WriteString(progress.writer, progress.cursorToStart()+strings.Join(lines, "\n"))
+	progress.linesDrawn = len(lines)
+}
+
+// Method to get the sequence moving the cursor to the start of the first drawn bar
+func (progress *Progress) cursorToStart() string {
+	if progress.linesDrawn > 1 {
+		return fmt.Sprintf("\r%s[%dA", escape, progress.linesDrawn-1)
+	}
+
+	return "\r"
+}
+
+// Method to get the sequence erasing all drawn bars
+func (progress *Progress) clearSequence() string {
+	if progress.linesDrawn == 0 {
+		return ""
+	}
+
+	return progress.cursorToStart() + escape + "[J"
+}
+
+// Method to print a plain unstyled status line for a bar. Lines are printed once per progress value
+func (progress *Progress) writePlain(bar *ProgressBar) {
+	state := bar.snapshot()
+
+	bar.mutex.Lock()
+	if bar.plainPrinted && bar.lastPlain == state.current && !state.finished {
+		bar.mutex.Unlock()
+		return
+	}
+	bar.plainPrinted, bar.lastPlain = true, state.current
+	bar.mutex.Unlock()
+
+	io.WriteString(progress.writer, formatPlainProgress(state)+"\n")
+}
+
+// Method to render a styled bar line
+func (progress *Progress) renderBar(state progressSnapshot) string {
+	style := progress.Style
+	var parts []string
+	if state.label != "" {
+		parts = append(parts, overlayStyle(style.Label, state.label))
+	}
+
+	if state.total > 0 {
+		ratio := progressRatio(state)
+		if style.Width > 0 {
+			filled := int(ratio * float64(style.Width))
+			bar := overlayStyle(style.Filled, strings.Repeat(style.FilledChar, filled)) +
+				overlayStyle(style.Empty, strings.Repeat(style.EmptyChar, style.Width-filled))
+			parts = append(parts, bar)
+		}
+		parts = append(parts, overlayStyle(style.Percentage, fmt.Sprintf("%3d%%", int(ratio*100))))
+	} else {
+		parts = append(parts, overlayStyle(style.Percentage, fmt.Sprint(state.current)))
+	}
+
+	parts = append(parts, overlayStyle(style.Details, progressDetails(state)))
+
+	return strings.Join(parts, " ")
+}
+
+// Method to get the completed part of a bar with a total, between 0 and 1 even when the current value is out of range
+func progressRatio(state progressSnapshot) float64 {
+	return math.Max(math.Min(float64(state.current)/float64(state.total), 1), 0)
+}
+
+// Method to format an unstyled progress line used when not on a terminal
+func formatPlainProgress(state progressSnapshot) string {
+	var parts []string
+	if state.label != "" {
+		parts = append(parts, state.label)
+	}
+	if state.total > 0 {
+		ratio := progressRatio(state)
+		parts = append(parts, fmt.Sprintf("%d%% (%d/%d)", int(ratio*100), state.current, state.total))
+	} else {
+		parts = append(parts, fmt.Sprint(state.current))
+	}
+
+	return strings.Join(append(parts, progressDetails(state)), " ")
+}
+
+// Method to format rate and ETA, or elapsed time for finished bars
+func progressDetails(state progressSnapshot) string {
+	if state.finished {
+		return "done in " + formatDuration(state.elapsed)
+	}
+
+	details := fmt.Sprintf("%.1f/s", state.rate)
+	if state.total > 0 && state.rate > 0 {
+		remaining := float64(state.total-state.current) / state.rate
+		details += " ETA " + formatDuration(time.Duration(remaining*float64(time.Second)))
+	}
+
+	return details
+}
+
+// Method to format a duration rounded to seconds
+func formatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return "0s"
+	}
+
+	return duration.Round(time.Second).String()
+}
@@ -0,0 +1,244 @@
+package gochalk
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock controlled by tests. After returns channels which fire when time is advanced past their deadline
+type fakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.now
+}
+
+func (clock *fakeClock) After(duration time.Duration) <-chan time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	channel := make(chan time.Time, 1)
+	clock.waiters = append(clock.waiters, fakeWaiter{deadline: clock.now.Add(duration), channel: channel})
+
+	return channel
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = clock.now.Add(duration)
+	var pending []fakeWaiter
+	for _, waiter := range clock.waiters {
+		if waiter.deadline.After(clock.now) {
+			pending = append(pending, waiter)
+		} else {
+			waiter.channel <- clock.now
+		}
+	}
+	clock.waiters = pending
+}
+
+// Method to get the number of pending After calls
+func (clock *fakeClock) Waiting() int {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return len(clock.waiters)
+}
+
+// Writer safe for concurrent use in tests
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (writer *syncBuffer) Write(data []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	return writer.buffer.Write(data)
+}
+
+func (writer *syncBuffer) String() string {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	return writer.buffer.String()
+}
+
+func newTestProgress(terminal bool) (*Progress, *syncBuffer, *fakeClock) {
+	writer := &syncBuffer{}
+	clock := newFakeClock()
+	progress := NewProgress(writer)
+	progress.Terminal = terminal
+	progress.Clock = clock
+	progress.Style.Width = 10
+
+	return progress, writer, clock
+}
+
+func TestProgress_Terminal(t *testing.T) {
+	progress, writer, clock := newTestProgress(true)
+	progress.Style = ProgressStyle{Width: 10, FilledChar: "#", EmptyChar: "-"}
+
+	bar := progress.AddBar("copy", 100)
+	clock.Advance(time.Second)
+	bar.Add(50)
+
+	actualString := writer.String()
+	expectedString := "\r\x1b[2Kcopy #####----- " + " 50% 50.0/s ETA 1s"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	clock.Advance(50 * time.Millisecond)
+	bar.Add(1)
+	if writer.String() != expectedString {
+		t.Error("\nExpected: Redraw to be throttled\nActual: Bar was redrawn")
+	}
+
+	progress.AddBar("second", 0)
+	bar.Finish()
+	progress.Stop()
+
+	actualString = strings.TrimPrefix(writer.String(), expectedString)
+	expectedString = "\r\x1b[2Kcopy ########## 100% done in 1s\n\x1b[2Ksecond 0 0.0/s" +
+		"\r\x1b[1A\x1b[2Kcopy ########## 100% done in 1s\n\x1b[2Ksecond 0 0.0/s\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestProgress_Styles(t *testing.T) {
+	progress, writer, _ := newTestProgress(true)
+
+	progress.AddBar("", 10).Finish()
+
+	actualString := writer.String()
+	expectedParts := []string{
+		StyledString(strings.Repeat("█", 10), FgGreen),
+		StyledString("100%", Bold),
+		StyledString("done in 0s", FgBrightBlack),
+	}
+	for _, expected := range expectedParts {
+		if !strings.Contains(actualString, expected) {
+			t.Errorf("\nExpected: %q\nActual: %q", expected, actualString)
+		}
+	}
+}
+
+func TestProgress_OutOfRange(t *testing.T) {
+	progress, writer, clock := newTestProgress(true)
+	progress.Style = ProgressStyle{Width: 10, FilledChar: "#", EmptyChar: "-"}
+
+	bar := progress.AddBar("copy", 100)
+	bar.Set(-20)
+	expectedString := "copy ----------   0%"
+	if actualString := writer.String(); !strings.Contains(actualString, expectedString) {
+		t.Errorf("\nExpected to contain: %q\nActual: %q", expectedString, actualString)
+	}
+
+	clock.Advance(time.Second)
+	bar.Set(250)
+	expectedString = "copy ########## 100%"
+	if actualString := writer.String(); !strings.Contains(actualString, expectedString) {
+		t.Errorf("\nExpected to contain: %q\nActual: %q", expectedString, actualString)
+	}
+
+	clock.Advance(time.Second)
+	progress.Style.Width = -5
+	bar.Set(40)
+	expectedString = "copy  40%"
+	if actualString := writer.String(); !strings.Contains(actualString, expectedString) {
+		t.Errorf("\nExpected to contain: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString := formatPlainProgress(progressSnapshot{label: "copy", total: 100, current: -20})
+	if expectedString := "copy 0% (-20/100) 0.0/s"; strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestProgress_Plain(t *testing.T) {
+	progress, writer, clock := newTestProgress(false)
+	progress.PlainInterval = 10 * time.Second
+
+	bar := progress.AddBar("migrate", 200)
+	clock.Advance(2 * time.Second)
+	bar.Add(20)
+	clock.Advance(2 * time.Second)
+	bar.Add(20)
+	clock.Advance(8 * time.Second)
+	bar.Add(40)
+	bar.Finish()
+	progress.Stop()
+
+	actualString := writer.String()
+	expectedString := "migrate 10% (20/200) 10.0/s ETA 18s\n" +
+		"migrate 40% (80/200) 6.0/s ETA 20s\n" +
+		"migrate 100% (200/200) done in 12s\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestProgress_Println(t *testing.T) {
+	progress, writer, _ := newTestProgress(true)
+	progress.Style = ProgressStyle{Width: 2, FilledChar: "#", EmptyChar: "-"}
+
+	progress.AddBar("a", 2)
+	progress.AddBar("b", 2)
+	progress.Println("log line")
+
+	actualString := writer.String()
+	expectedString := "log line\n\r\x1b[2Ka -- " + "  0% 0.0/s\n\x1b[2Kb --   0% 0.0/s"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	progress.Println("next")
+	if !strings.Contains(writer.String(), "\r\x1b[1A\x1b[Jnext\n") {
+		t.Errorf("\nExpected: Bars to be cleared before printing\nActual: %q", writer.String())
+	}
+}
+
+func TestProgress_Concurrent(t *testing.T) {
+	progress, writer, _ := newTestProgress(false)
+	bar := progress.AddBar("work", 1000)
+
+	var group sync.WaitGroup
+	for worker := 0; worker < 10; worker++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for count := 0; count < 100; count++ {
+				bar.Add(1)
+			}
+		}()
+	}
+	group.Wait()
+	bar.Finish()
+
+	if !strings.HasSuffix(writer.String(), "work 100% (1000/1000) done in 0s\n") {
+		t.Errorf("\nExpected: Finished line\nActual: %q", writer.String())
+	}
+}
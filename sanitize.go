@@ -0,0 +1,112 @@
+package gochalk
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Way in which Sanitize neutralizes escape sequences and control characters
+type SanitizeMode int
+
+const (
+	// Remove escape sequences and control characters
+	SanitizeStrip SanitizeMode = iota
+	// Replace control characters with caret notation, e.g. ESC becomes "^[" and CSI becomes "^[["
+	SanitizeCaret
+	// Replace control characters with Go escapes, e.g. ESC becomes "\x1b" and CSI becomes "\u009b"
+	SanitizeEscape
+)
+
+// Method to neutralize escape sequences and C0 / C1 control characters in untrusted text so it can't
+// change colors, move the cursor, set the window title or create hyperlinks when printed.
+// C1 controls sent as single 8-bit bytes, which are invalid UTF-8 but run by some terminals, are neutralized too.
+// Newline and tab are kept as is.
+//
+//	gochalk.Red("User: " + gochalk.Sanitize(username, gochalk.SanitizeCaret))
+func Sanitize(value string, mode SanitizeMode) string {
+	var builder strings.Builder
+	for _, token := range Tokenize(value) {
+		switch {
+		case token.Kind == TokenText:
+			sanitizeText(&builder, token.Raw, mode)
+		case token.Raw == "\n" || token.Raw == "\t":
+			builder.WriteString(token.Raw)
+		case mode == SanitizeStrip:
+			continue
+		default:
+			for _, char := range token.Raw {
+				builder.WriteString(visibleControl(char, mode))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// Creates a new Chalk which sanitizes every string passed to ToString and Println using the given mode before styling
+//
+//	username := gochalk.NewStyle(gochalk.Bold).Safe(gochalk.SanitizeCaret)
+//	username.Println(nameFromNetwork)
+func (chalk *Chalk) Safe(mode SanitizeMode) *Chalk {
+	newChalk := *chalk
+	newChalk.safe = true
+	newChalk.sanitizeMode = mode
+
+	return &newChalk
+}
+
+// Method to sanitize each of the given strings
+func sanitizeAll(mode SanitizeMode, values ...string) []string {
+	sanitized := make([]string, len(values))
+	for index, value := range values {
+		sanitized[index] = Sanitize(value, mode)
+	}
+
+	return sanitized
+}
+
+// Method to write text, neutralizing raw 8-bit C1 control bytes
+func sanitizeText(builder *strings.Builder, text string, mode SanitizeMode) {
+	for index := 0; index < len(text); {
+		char, size := utf8.DecodeRuneInString(text[index:])
+		if char != utf8.RuneError || size != 1 || text[index] < 0x80 || text[index] >= 0xa0 {
+			builder.WriteString(text[index : index+size])
+			index += size
+			continue
+		}
+
+		switch mode {
+		case SanitizeStrip:
+		case SanitizeEscape:
+			fmt.Fprintf(builder, `\x%02x`, text[index])
+		default:
+			builder.WriteString(visibleControl(rune(text[index]), mode))
+		}
+		index++
+	}
+}
+
+// Method to get the visible representation of a character from a control token
+func visibleControl(char rune, mode SanitizeMode) string {
+	isControl := char < 0x20 || char == 0x7f || (char >= 0x80 && char < 0xa0)
+	if !isControl {
+		return string(char)
+	}
+
+	if mode == SanitizeEscape {
+		if char < 0x80 {
+			return fmt.Sprintf(`\x%02x`, char)
+		}
+		return fmt.Sprintf(`\u%04x`, char)
+	}
+
+	switch {
+	case char == 0x7f:
+		return "^?"
+	case char >= 0x80:
+		return "^[" + string(char-0x40)
+	default:
+		return "^" + string(char+0x40)
+	}
+}
@@ -0,0 +1,91 @@
+package gochalk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const maliciousString = "evil\x1b]0;pwned\x07\x1b[2J\r\u009bname\n\tend\x7f"
+
+func TestSanitize_Strip(t *testing.T) {
+	actualString := Sanitize(maliciousString, SanitizeStrip)
+	expectedString := "evilname\n\tend"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSanitize_Caret(t *testing.T) {
+	actualString := Sanitize(maliciousString, SanitizeCaret)
+	expectedString := "evil^[]0;pwned^G^[[2J^M^[[name\n\tend^?"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSanitize_Escape(t *testing.T) {
+	actualString := Sanitize(maliciousString, SanitizeEscape)
+	expectedString := `evil\x1b]0;pwned\x07\x1b[2J\x0d\u009bname` + "\n\tend" + `\x7f`
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSanitize_RawC1Bytes(t *testing.T) {
+	value := "a\x9b31mb\x9d0;title\x9cc\xffd\u0085e"
+	tests := []struct {
+		mode     SanitizeMode
+		expected string
+	}{
+		{SanitizeStrip, "a31mb0;titlec\xffde"},
+		{SanitizeCaret, "a^[[31mb^[]0;title^[\\c\xffd^[Ee"},
+		{SanitizeEscape, `a\x9b31mb\x9d0;title\x9cc` + "\xff" + `d\u0085e`},
+	}
+	for _, test := range tests {
+		actualString := Sanitize(value, test.mode)
+		if strings.Compare(actualString, test.expected) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", test.expected, actualString)
+		}
+	}
+}
+
+func TestSanitize_PlainText(t *testing.T) {
+	actualString := Sanitize("héllo wörld ✔", SanitizeCaret)
+	expectedString := "héllo wörld ✔"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSafe(t *testing.T) {
+	safeChalk := NewStyle(FgRed).Safe(SanitizeStrip)
+
+	actualString := safeChalk.ToString("user", "\x1b[5mname")
+	expectedString := fmt.Sprintf("%s[%dm%s%s", escape, FgRed, "user name", resetStyle)
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSafe_KeptByAddAndRemove(t *testing.T) {
+	safeChalk := NewStyle(FgRed).Safe(SanitizeCaret).Add(Bold).Remove(FgRed)
+
+	actualString := safeChalk.ToString("\x1b")
+	expectedString := fmt.Sprintf("%s[%dm%s%s", escape, Bold, "^[", resetStyle)
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+	if actualString := safeChalk.RemoveAll().ToString("\x1b"); actualString != "^[" {
+		t.Errorf("\nExpected: %q\nActual: %q", "^[", actualString)
+	}
+	if actualString := NewStyle(FgRed).ToString("\x1b"); actualString == fmt.Sprintf("%s[%dm%s%s", escape, FgRed, "^[", resetStyle) {
+		t.Error("\nExpected: Chalk without safe mode should not sanitize\nActual: String was sanitized")
+	}
+}
@@ -0,0 +1,214 @@
+package gochalk
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Kind of color held by a colorSpec
+const (
+	colorDefault = iota
+	colorIndexed
+	colorRGB
+)
+
+// A foreground or background color as set by SGR sequences
+type colorSpec struct {
+	kind  int
+	index uint8
+	rgb   Color
+}
+
+// Method to resolve the color to RGB using the given palette
+func (spec colorSpec) resolve(palette *Palette) Color {
+	if spec.kind == colorRGB {
+		return spec.rgb
+	}
+
+	return palette.Color256(spec.index)
+}
+
+// Text attributes active at a point in a string, tracked while reading SGR sequences
+type sgrState struct {
+	fg            colorSpec
+	bg            colorSpec
+	bold          bool
+	dim           bool
+	italic        bool
+	underline     bool
+	strikethrough bool
+	inverse       bool
+	hidden        bool
+}
+
+// Method to check if no attribute is set
+func (state sgrState) isDefault() bool {
+	return state == sgrState{}
+}
+
+// Method to get effective foreground and background after applying inverse.
+// Default colors are replaced with palette white / black when inverted
+func (state sgrState) colors() (colorSpec, colorSpec) {
+	if !state.inverse {
+		return state.fg, state.bg
+	}
+
+	fg, bg := state.bg, state.fg
+	if fg.kind == colorDefault {
+		fg = colorSpec{kind: colorIndexed, index: 0}
+	}
+	if bg.kind == colorDefault {
+		bg = colorSpec{kind: colorIndexed, index: 7}
+	}
+
+	return fg, bg
+}
+
+// Method to update state with the styles of a single SGR sequence
+func (state *sgrState) apply(styles []Style) {
+	for _, style := range styles {
+		value := style & styleValueMask
+		switch style >> styleKindShift {
+		case styleKindFg256:
+			state.fg = colorSpec{kind: colorIndexed, index: uint8(value)}
+			continue
+		case styleKindBg256:
+			state.bg = colorSpec{kind: colorIndexed, index: uint8(value)}
+			continue
+		case styleKindFgRGB:
+			state.fg = colorSpec{kind: colorRGB, rgb: styleRGB(style)}
+			continue
+		case styleKindBgRGB:
+			state.bg = colorSpec{kind: colorRGB, rgb: styleRGB(style)}
+			continue
+		}
+
+		switch {
+		case style == Reset:
+			*state = sgrState{}
+		case style == Bold:
+			state.bold = true
+		case style == Dim:
+			state.dim = true
+		case style == Italics:
+			state.italic = true
+		case style == Underlined || style == 21:
+			state.underline = true
+		case style == Inverse:
+			state.inverse = true
+		case style == Hidden:
+			state.hidden = true
+		case style == Strikethrough:
+			state.strikethrough = true
+		case style == 22:
+			state.bold, state.dim = false, false
+		case style == 23:
+			state.italic = false
+		case style == 24:
+			state.underline = false
+		case style == 27:
+			state.inverse = false
+		case style == 28:
+			state.hidden = false
+		case style == 29:
+			state.strikethrough = false
+		case style >= FgBlack && style <= FgWhite:
+			state.fg = colorSpec{kind: colorIndexed, index: uint8(style - FgBlack)}
+		case style >= FgBrightBlack && style <= FgBrightWhite:
+			state.fg = colorSpec{kind: colorIndexed, index: uint8(style - FgBrightBlack + 8)}
+		case style >= BgBlack && style <= BgWhite:
+			state.bg = colorSpec{kind: colorIndexed, index: uint8(style - BgBlack)}
+		case style >= BgBrightBlack && style <= BgBrightWhite:
+			state.bg = colorSpec{kind: colorIndexed, index: uint8(style - BgBrightBlack + 8)}
+		case style == 39:
+			state.fg = colorSpec{}
+		case style == 49:
+			state.bg = colorSpec{}
+		}
+	}
+}
+
+// Method to get the RGB value stored in a 24-bit color style
+func styleRGB(style Style) Color {
+	value := style & styleValueMask
+	return Color{uint8(value >> 16), uint8(value >> 8 & 0xff), uint8(value & 0xff)}
+}
+
+// Method to convert the parameter string of an SGR sequence to styles.
+// 38 / 48 parameters are combined with their arguments into extended color styles and dropped if malformed
+func parseSGRStyles(raw string) []Style {
+	params := parseSGRParams(raw)
+	if len(params) == 0 {
+		return []Style{Reset}
+	}
+
+	styles := make([]Style, 0, len(params))
+	for index := 0; index < len(params); index++ {
+		param := params[index]
+		if param < 0 || param > 255 {
+			continue
+		}
+		if param != 38 && param != 48 {
+			styles = append(styles, Style(param))
+			continue
+		}
+
+		rest := params[index+1:]
+		switch {
+		case len(rest) >= 2 && rest[0] == 5 && param == 38:
+			styles = append(styles, Fg256(clampByte(rest[1])))
+			index += 2
+		case len(rest) >= 2 && rest[0] == 5:
+			styles = append(styles, Bg256(clampByte(rest[1])))
+			index += 2
+		case len(rest) >= 4 && rest[0] == 2 && param == 38:
+			styles = append(styles, FgRGB(clampByte(rest[1]), clampByte(rest[2]), clampByte(rest[3])))
+			index += 4
+		case len(rest) >= 4 && rest[0] == 2:
+			styles = append(styles, BgRGB(clampByte(rest[1]), clampByte(rest[2]), clampByte(rest[3])))
+			index += 4
+		default:
+			index = len(params)
+		}
+	}
+
+	return styles
+}
+
+// Method to parse the parameter string of a CSI sequence such as "1;31" into numbers.
+// Empty parameters are treated as 0 and invalid ones as -1. ':' separated sub-parameters ("38:2::255:0:0") are flattened
+// into the ';' form with the optional color space id dropped
+func parseSGRParams(raw string) []int {
+	if raw == "" {
+		return nil
+	}
+
+	var params []int
+	for _, field := range strings.Split(raw, ";") {
+		subFields := strings.Split(field, ":")
+		if len(subFields) == 6 && (subFields[0] == "38" || subFields[0] == "48") && subFields[1] == "2" {
+			subFields = append(subFields[:2], subFields[3:]...)
+		}
+		for _, subField := range subFields {
+			value, err := strconv.Atoi(subField)
+			if subField != "" && err != nil {
+				value = -1
+			}
+			params = append(params, value)
+		}
+	}
+
+	return params
+}
+
+// Method to limit an int to the 0-255 range
+func clampByte(value int) uint8 {
+	if value < 0 {
+		return 0
+	}
+	if value > 255 {
+		return 255
+	}
+
+	return uint8(value)
+}
@@ -0,0 +1,292 @@
+package gochalk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Labeled range of a source pointed at by Snippet
+type Annotation struct {
+	// Byte offsets of the range in the source. End is exclusive, an empty range points at Start
+	Start int
+	End   int
+	Label string
+	// Secondary annotations give context to the primary ones and are drawn with - instead of ^
+	Secondary bool
+}
+
+// Options for rendering source snippets
+type SnippetOptions struct {
+	// Path of the source, shown above the snippet with the line and column of the first primary annotation
+	Path string
+	// Lines shown before and after annotated lines. Defaults to 1, a negative value shows only annotated lines
+	ContextLines int
+	// Styles of the line numbers and gutter, primary annotations and secondary annotations
+	Gutter    *Chalk
+	Primary   *Chalk
+	Secondary *Chalk
+	// Color level of the output, detected for stdout by default
+	Level ColorLevel
+}
+
+// Part of an annotation on a single line, with display columns
+type snippetMarker struct {
+	start     int
+	end       int
+	label     string
+	secondary bool
+}
+
+// Method to render an excerpt of source with line numbers and annotations underlining byte ranges, in the style of
+// rustc. Annotations on the same line are drawn side by side, with their labels connected below. Annotations over
+// several lines underline each line and show their label on the last one. Passing nil options uses the default styles
+//
+//	fmt.Println(gochalk.Snippet(config, []gochalk.Annotation{
+//		{Start: 27, End: 31, Label: "expected a number"},
+//	}, &gochalk.SnippetOptions{Path: "config.yaml"}))
+//
+// Output:
+//
+//	 --> config.yaml:3:9
+//	  |
+//	2 | server:
+//	3 |   port: "80"
+//	  |         ^^^^ expected a number
+//	4 |   host: localhost
+func Snippet(source string, annotations []Annotation, options *SnippetOptions) string {
+	options = options.withDefaults()
+	lines := strings.Split(source, "\n")
+	starts := make([]int, len(lines))
+	for index := 1; index < len(lines); index++ {
+		starts[index] = starts[index-1] + len(lines[index-1]) + 1
+	}
+	for index, line := range lines {
+		lines[index] = strings.TrimSuffix(line, "\r")
+	}
+	lineOf := func(offset int) int {
+		return sort.Search(len(starts), func(index int) bool { return starts[index] > offset }) - 1
+	}
+
+	// The location shown with the path is the first primary annotation, or the first annotation if all are secondary
+	located := 0
+	for index, annotation := range annotations {
+		if !annotation.Secondary {
+			located = index
+			break
+		}
+	}
+
+	markers := map[int][]snippetMarker{}
+	header := ""
+	for index, annotation := range annotations {
+		start := min(max(annotation.Start, 0), len(source))
+		end := min(max(annotation.End, start), len(source))
+		startLine, endLine := lineOf(start), lineOf(end)
+		if end > start && end == starts[endLine] && endLine > startLine {
+			// A range ending with a newline ends on the line before
+			endLine--
+		}
+		if index == located {
+			column := utf8.RuneCountInString(lines[startLine][:min(start-starts[startLine], len(lines[startLine]))]) + 1
+			header = fmt.Sprintf("%d:%d", startLine+1, column)
+		}
+
+		for line := startLine; line <= endLine; line++ {
+			text := lines[line]
+			from, to := 0, len(text)
+			if line == startLine {
+				from = min(start-starts[line], len(text))
+			}
+			if line == endLine {
+				to = min(max(end-starts[line], from), len(text))
+			}
+			if from == to && startLine != endLine && line != endLine {
+				continue
+			}
+
+			marker := snippetMarker{start: snippetColumn(text[:from]), end: snippetColumn(text[:to]), secondary: annotation.Secondary}
+			if marker.end == marker.start {
+				marker.end++
+			}
+			if line == endLine {
+				marker.label = annotation.Label
+			}
+			markers[line] = append(markers[line], marker)
+		}
+	}
+
+	// Lines shown, with their context. The empty line after a trailing newline is only shown when annotated
+	last := len(lines) - 1
+	if last > 0 && lines[last] == "" {
+		last--
+	}
+	shown := map[int]bool{}
+	for line := range markers {
+		shown[line] = true
+		for context := max(line-options.ContextLines, 0); context <= min(line+options.ContextLines, last); context++ {
+			shown[context] = true
+		}
+	}
+	var numbers []int
+	for line := range shown {
+		numbers = append(numbers, line)
+	}
+	sort.Ints(numbers)
+
+	width := 1
+	if len(numbers) > 0 {
+		width = len(strconv.Itoa(numbers[len(numbers)-1] + 1))
+	}
+	gutter := func(number string) string {
+		return paintStyle(options.Level, options.Gutter, fmt.Sprintf(" %*s |", width, number))
+	}
+
+	var output []string
+	if options.Path != "" {
+		location := options.Path
+		if header != "" {
+			location += ":" + header
+		}
+		output = append(output, strings.Repeat(" ", width+1)+paintStyle(options.Level, options.Gutter, "-->")+" "+location)
+	}
+	output = append(output, gutter(""))
+	for index, line := range numbers {
+		if index > 0 && line > numbers[index-1]+1 {
+			output = append(output, paintStyle(options.Level, options.Gutter, strings.Repeat(".", width+2)))
+		}
+		text := strings.ReplaceAll(lines[line], "\t", "    ")
+		output = append(output, strings.TrimRight(gutter(strconv.Itoa(line+1))+" "+text, " "))
+		for _, marked := range options.markerLines(markers[line]) {
+			output = append(output, gutter("")+" "+marked)
+		}
+	}
+
+	return strings.Join(output, "\n")
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *SnippetOptions) withDefaults() *SnippetOptions {
+	result := SnippetOptions{}
+	if options != nil {
+		result = *options
+	}
+	switch {
+	case result.ContextLines == 0:
+		result.ContextLines = 1
+	case result.ContextLines < 0:
+		result.ContextLines = 0
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	fillDefaultStyles([]defaultStyle{
+		{&result.Gutter, NewStyle(FgBlue, Bold)},
+		{&result.Primary, NewStyle(FgRed, Bold)},
+		{&result.Secondary, NewStyle(FgBlue, Bold)},
+	})
+
+	return &result
+}
+
+// Method to draw the underlines of a line and their labels. The label of the rightmost marker follows its underline,
+// the others are connected with | and placed on lines below, from right to left
+func (options *SnippetOptions) markerLines(markers []snippetMarker) []string {
+	if len(markers) == 0 {
+		return nil
+	}
+	sort.SliceStable(markers, func(first, second int) bool { return markers[first].start < markers[second].start })
+
+	style := func(marker snippetMarker) *Chalk {
+		if marker.secondary {
+			return options.Secondary
+		}
+		return options.Primary
+	}
+
+	// Cells of a line, each drawn in the style of the marker it belongs to
+	type cell struct {
+		text   string
+		marker *snippetMarker
+	}
+	render := func(cells []cell) string {
+		var builder strings.Builder
+		for index := 0; index < len(cells); {
+			next := index
+			var run strings.Builder
+			for next < len(cells) && cells[next].marker == cells[index].marker {
+				run.WriteString(cells[next].text)
+				next++
+			}
+			if cells[index].marker == nil {
+				builder.WriteString(run.String())
+			} else {
+				builder.WriteString(paintStyle(options.Level, style(*cells[index].marker), run.String()))
+			}
+			index = next
+		}
+		return strings.TrimRight(builder.String(), " ")
+	}
+	place := func(cells []cell, column int, text string, marker *snippetMarker) []cell {
+		for len(cells) < column {
+			cells = append(cells, cell{text: " "})
+		}
+		cells = append(cells[:column], cell{text: text, marker: marker})
+		return cells
+	}
+
+	var underline []cell
+	for index := range markers {
+		marker := &markers[index]
+		symbol := "^"
+		if marker.secondary {
+			symbol = "-"
+		}
+		for column := marker.start; column < marker.end; column++ {
+			for len(underline) <= column {
+				underline = append(underline, cell{text: " "})
+			}
+			underline[column] = cell{text: symbol, marker: marker}
+		}
+	}
+
+	// The rightmost label follows the underline, unless another underline is drawn after it
+	var pending []*snippetMarker
+	for index := range markers {
+		if markers[index].label != "" {
+			pending = append(pending, &markers[index])
+		}
+	}
+	if last := len(pending) - 1; last >= 0 && pending[last].end >= len(underline) {
+		underline = append(underline, cell{text: " " + pending[last].label, marker: pending[last]})
+		pending = pending[:last]
+	}
+	result := []string{render(underline)}
+	if len(pending) == 0 {
+		return result
+	}
+
+	var connectors []cell
+	for _, marker := range pending {
+		connectors = place(connectors, marker.start, "|", marker)
+	}
+	result = append(result, render(connectors))
+	for last := len(pending) - 1; last >= 0; last-- {
+		var cells []cell
+		for _, marker := range pending[:last] {
+			cells = place(cells, marker.start, "|", marker)
+		}
+		cells = place(cells, pending[last].start, pending[last].label, pending[last])
+		result = append(result, render(cells))
+	}
+
+	return result
+}
+
+// Method to get the display column after text, with tabs expanded to four spaces
+func snippetColumn(text string) int {
+	return StringWidth(strings.ReplaceAll(text, "\t", "    "))
+}
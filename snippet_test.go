@@ -0,0 +1,101 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSnippetSource = "version: 2\nserver:\n  port: \"80\"\n  host: localhost\nlimits:\n\tmax: 5 + x\n"
+
+func TestSnippet(t *testing.T) {
+	actualString := Snippet(testSnippetSource, []Annotation{
+		{Start: 27, End: 31, Label: "expected a number"},
+	}, &SnippetOptions{Path: "config.yaml", Level: ColorLevelNone})
+	expectedString := `  --> config.yaml:3:9
+   |
+ 2 | server:
+ 3 |   port: "80"
+   |         ^^^^ expected a number
+ 4 |   host: localhost`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestSnippet_SameLine(t *testing.T) {
+	actualString := Snippet(testSnippetSource, []Annotation{
+		{Start: 64, End: 65, Label: "left operand"},
+		{Start: 68, End: 69, Label: "undefined", Secondary: true},
+		{Start: 66, End: 67},
+	}, &SnippetOptions{Level: ColorLevelNone, ContextLines: -1})
+	expectedString := `   |
+ 6 |     max: 5 + x
+   |          ^ ^ - undefined
+   |          |
+   |          left operand`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+
+	actualString = Snippet(testSnippetSource, []Annotation{
+		{Start: 64, End: 65, Label: "a"},
+		{Start: 66, End: 67, Label: "b"},
+		{Start: 68, End: 69},
+	}, &SnippetOptions{Level: ColorLevelNone, ContextLines: -1})
+	expectedString = `   |
+ 6 |     max: 5 + x
+   |          ^ ^ ^
+   |          | |
+   |          | b
+   |          a`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestSnippet_MultiLine(t *testing.T) {
+	actualString := Snippet(testSnippetSource, []Annotation{
+		{Start: 0, End: 10, Label: "declared here", Secondary: true},
+		{Start: 11, End: 49, Label: "unknown section"},
+	}, &SnippetOptions{Path: "config.yaml", Level: ColorLevelNone, ContextLines: -1})
+	expectedString := `  --> config.yaml:2:1
+   |
+ 1 | version: 2
+   | ---------- declared here
+ 2 | server:
+   | ^^^^^^^
+ 3 |   port: "80"
+   | ^^^^^^^^^^^^
+ 4 |   host: localhost
+   | ^^^^^^^^^^^^^^^^^ unknown section`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestSnippet_Gap(t *testing.T) {
+	actualString := Snippet(testSnippetSource, []Annotation{
+		{Start: 0, End: 7},
+		{Start: 59, End: 59, Label: "here"},
+	}, &SnippetOptions{Level: ColorLevelNone, ContextLines: -1})
+	expectedString := `   |
+ 1 | version: 2
+   | ^^^^^^^
+...
+ 6 |     max: 5 + x
+   |     ^ here`
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+	}
+}
+
+func TestSnippet_Styles(t *testing.T) {
+	actualString := Snippet("a = 1", []Annotation{{Start: 4, End: 5, Label: "here"}}, &SnippetOptions{Level: ColorLevel16})
+	gutter := NewStyle(FgBlue, Bold)
+	expectedString := gutter.ToString("   |") + "\n" +
+		gutter.ToString(" 1 |") + " a = 1\n" +
+		gutter.ToString("   |") + "     " + NewStyle(FgRed, Bold).ToString("^ here")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
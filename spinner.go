@@ -0,0 +1,192 @@
+package gochalk
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Frames of a spinner animation and the time each frame is shown
+type SpinnerFrames struct {
+	Frames   []string
+	Interval time.Duration
+}
+
+// Built-in spinner animations
+var (
+	SpinnerDots   = SpinnerFrames{Frames: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}, Interval: 80 * time.Millisecond}
+	SpinnerLine   = SpinnerFrames{Frames: []string{"-", "\\", "|", "/"}, Interval: 130 * time.Millisecond}
+	SpinnerArc    = SpinnerFrames{Frames: []string{"◜", "◠", "◝", "◞", "◡", "◟"}, Interval: 100 * time.Millisecond}
+	SpinnerCircle = SpinnerFrames{Frames: []string{"◐", "◓", "◑", "◒"}, Interval: 120 * time.Millisecond}
+	SpinnerArrow  = SpinnerFrames{Frames: []string{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}, Interval: 100 * time.Millisecond}
+	SpinnerBounce = SpinnerFrames{Frames: []string{"⠁", "⠂", "⠄", "⠂"}, Interval: 120 * time.Millisecond}
+)
+
+// Animated spinner shown next to a message while work is running. The animation runs on its own goroutine.
+// When the writer isn't a terminal nothing is animated and only the final status line is printed.
+// Exported fields should be set before calling Start
+type Spinner struct {
+	Frames SpinnerFrames
+	// Style of the spinner frames
+	Style *Chalk
+	// Symbols and styles printed by Success and Fail
+	SuccessSymbol string
+	SuccessStyle  *Chalk
+	FailSymbol    string
+	FailStyle     *Chalk
+	// Animate using escape sequences. Detected by NewSpinner
+	Terminal bool
+	Clock    Clock
+
+	mutex   sync.Mutex
+	writer  io.Writer
+	text    string
+	running bool
+	// Set while halt waits for the animation goroutine to exit. Start waits for stopped before animating again
+	stopping bool
+	stopped  *sync.Cond
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Creates a new Spinner writing to writer with the dots animation
+//
+//	spinner := gochalk.NewSpinner(os.Stderr, "Deploying")
+//	spinner.Start(ctx)
+//	if err := deploy(ctx); err != nil {
+//		spinner.Fail("Deploy failed")
+//		return err
+//	}
+//	spinner.Success("Deployed")
+func NewSpinner(writer io.Writer, text string) *Spinner {
+	file, _ := writer.(*os.File)
+
+	spinner := &Spinner{
+		Frames:        SpinnerDots,
+		Style:         NewStyle(FgCyan),
+		SuccessSymbol: "✔",
+		SuccessStyle:  NewStyle(FgGreen),
+		FailSymbol:    "✖",
+		FailStyle:     NewStyle(FgRed),
+		Terminal:      isTerminal(file),
+		Clock:         systemClock{},
+		writer:        writer,
+		text:          text,
+	}
+	spinner.stopped = sync.NewCond(&spinner.mutex)
+
+	return spinner
+}
+
+// Method to start the animation. The spinner stops and clears its line when ctx is cancelled.
+// Calling Start on a running spinner does nothing
+func (spinner *Spinner) Start(ctx context.Context) {
+	spinner.mutex.Lock()
+	defer spinner.mutex.Unlock()
+
+	for spinner.stopping {
+		spinner.stopped.Wait()
+	}
+	if spinner.running || !spinner.Terminal || len(spinner.Frames.Frames) == 0 {
+		return
+	}
+
+	spinner.running = true
+	spinner.stop = make(chan struct{})
+	spinner.done = make(chan struct{})
+	io.WriteString(spinner.writer, escape+"[?25l")
+	go spinner.animate(ctx, spinner.stop, spinner.done)
+}
+
+// Method to change the message shown next to the spinner
+func (spinner *Spinner) SetText(text string) {
+	spinner.mutex.Lock()
+	defer spinner.mutex.Unlock()
+
+	spinner.text = text
+}
+
+// Method to stop the animation and clear the spinner line
+func (spinner *Spinner) Stop() {
+	spinner.mutex.Lock()
+	defer spinner.mutex.Unlock()
+
+	spinner.halt()
+}
+
+// Method to stop the animation and print text after a green ✔. Current message is used if text is empty
+func (spinner *Spinner) Success(text string) {
+	spinner.StopWith(spinner.SuccessSymbol, spinner.SuccessStyle, text)
+}
+
+// Method to stop the animation and print text after a red ✖. Current message is used if text is empty
+func (spinner *Spinner) Fail(text string) {
+	spinner.StopWith(spinner.FailSymbol, spinner.FailStyle, text)
+}
+
+// Method to stop the animation and print text after a custom status symbol, e.g. a yellow "⚠" for warnings
+func (spinner *Spinner) StopWith(symbol string, style *Chalk, text string) {
+	spinner.mutex.Lock()
+	defer spinner.mutex.Unlock()
+
+	spinner.halt()
+	if text == "" {
+		text = spinner.text
+	}
+
+	if spinner.Terminal {
+		io.WriteString(spinner.writer, overlayStyle(style, symbol)+" "+text+"\n")
+	} else {
+		io.WriteString(spinner.writer, symbol+" "+Strip(text)+"\n")
+	}
+}
+
+// Method to stop the animation goroutine and wait for it to exit. Caller must hold the mutex
+func (spinner *Spinner) halt() {
+	for spinner.stopping {
+		spinner.stopped.Wait()
+	}
+	if !spinner.running {
+		return
+	}
+
+	spinner.stopping = true
+	close(spinner.stop)
+	// The goroutine takes the mutex to draw frames, so it has to be released while waiting
+	done := spinner.done
+	spinner.mutex.Unlock()
+	<-done
+	spinner.mutex.Lock()
+
+	io.WriteString(spinner.writer, "\r"+escape+"[2K"+escape+"[?25h")
+	spinner.running, spinner.stopping = false, false
+	spinner.stopped.Broadcast()
+}
+
+// Method run on the animation goroutine, drawing a frame every interval until stopped or ctx is cancelled
+func (spinner *Spinner) animate(ctx context.Context, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	for frame := 0; ; frame++ {
+		spinner.mutex.Lock()
+		symbol := spinner.Frames.Frames[frame%len(spinner.Frames.Frames)]
+		io.WriteString(spinner.writer, "\r"+escape+"[2K"+overlayStyle(spinner.Style, symbol)+" "+spinner.text)
+		spinner.mutex.Unlock()
+
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			spinner.mutex.Lock()
+			if spinner.running && !spinner.stopping {
+				spinner.running = false
+				io.WriteString(spinner.writer, "\r"+escape+"[2K"+escape+"[?25h")
+			}
+			spinner.mutex.Unlock()
+			return
+		case <-spinner.Clock.After(spinner.Frames.Interval):
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gochalk
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSpinner(terminal bool) (*Spinner, *syncBuffer, *fakeClock) {
+	writer := &syncBuffer{}
+	clock := newFakeClock()
+	spinner := NewSpinner(writer, "Working")
+	spinner.Frames = SpinnerLine
+	spinner.Style = nil
+	spinner.Terminal = terminal
+	spinner.Clock = clock
+
+	return spinner, writer, clock
+}
+
+// Method to wait until condition is met by the spinner goroutine
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("\nExpected: Condition to be met\nActual: Timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSpinner_Animation(t *testing.T) {
+	spinner, writer, clock := newTestSpinner(true)
+
+	spinner.Start(context.Background())
+	waitFor(t, func() bool { return clock.Waiting() == 1 })
+	clock.Advance(SpinnerLine.Interval)
+	waitFor(t, func() bool { return strings.Contains(writer.String(), "\\ Working") })
+	spinner.SetText("Still working")
+	clock.Advance(SpinnerLine.Interval)
+	waitFor(t, func() bool { return strings.Contains(writer.String(), "| Still working") })
+	spinner.Success("Done")
+
+	actualString := writer.String()
+	expectedString := "\x1b[?25l\r\x1b[2K- Working\r\x1b[2K\\ Working\r\x1b[2K| Still working\r\x1b[2K\x1b[?25h" +
+		escapedStyle(FgGreen) + "✔" + resetStyle + " Done\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSpinner_Fail(t *testing.T) {
+	spinner, writer, clock := newTestSpinner(true)
+
+	spinner.Start(context.Background())
+	waitFor(t, func() bool { return clock.Waiting() == 1 })
+	spinner.Fail("")
+
+	actualString := writer.String()
+	expectedString := "\x1b[?25l\r\x1b[2K- Working\r\x1b[2K\x1b[?25h" + escapedStyle(FgRed) + "✖" + resetStyle + " Working\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+// Clock whose After blocks until the gate is closed, keeping the animation goroutine from noticing Stop
+type gatedClock struct {
+	*fakeClock
+	gate chan struct{}
+}
+
+func (clock gatedClock) After(duration time.Duration) <-chan time.Time {
+	<-clock.gate
+	return clock.fakeClock.After(duration)
+}
+
+func TestSpinner_RestartWhileStopping(t *testing.T) {
+	spinner, writer, clock := newTestSpinner(true)
+	gate := make(chan struct{})
+	spinner.Clock = gatedClock{clock, gate}
+
+	spinner.Start(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		spinner.Stop()
+		close(stopped)
+	}()
+	waitFor(t, func() bool {
+		spinner.mutex.Lock()
+		defer spinner.mutex.Unlock()
+		return spinner.stopping
+	})
+
+	started := make(chan struct{})
+	go func() {
+		spinner.Start(context.Background())
+		close(started)
+	}()
+	// Gives Start the chance to run while Stop is waiting for the animation goroutine
+	time.Sleep(10 * time.Millisecond)
+	close(gate)
+	<-stopped
+	<-started
+
+	expectedString := "\x1b[?25l\r\x1b[2K- Working\r\x1b[2K\x1b[?25h\x1b[?25l"
+	waitFor(t, func() bool { return strings.HasPrefix(writer.String(), expectedString) })
+	spinner.Stop()
+	if actualString := writer.String(); !strings.HasSuffix(actualString, "\r\x1b[2K\x1b[?25h") {
+		t.Errorf("\nExpected: Cursor shown after stopping\nActual: %q", actualString)
+	}
+}
+
+func TestSpinner_ContextCancelled(t *testing.T) {
+	spinner, writer, clock := newTestSpinner(true)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	spinner.Start(ctx)
+	waitFor(t, func() bool { return clock.Waiting() == 1 })
+	cancel()
+	waitFor(t, func() bool { return strings.HasSuffix(writer.String(), "\x1b[?25h") })
+	spinner.Stop()
+
+	actualString := writer.String()
+	expectedString := "\x1b[?25l\r\x1b[2K- Working\r\x1b[2K\x1b[?25h"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestSpinner_NotTerminal(t *testing.T) {
+	spinner, writer, clock := newTestSpinner(false)
+
+	spinner.Start(context.Background())
+	if clock.Waiting() != 0 {
+		t.Error("\nExpected: Spinner should not animate\nActual: Spinner is animating")
+	}
+	spinner.Success(Green("Deployed"))
+
+	actualString := writer.String()
+	expectedString := "✔ Deployed\n"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
@@ -0,0 +1,225 @@
+package gochalk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Options for formatting panics and stack traces
+type StackOptions struct {
+	// Style of panic and fatal error messages
+	Message *Chalk
+	// Style of goroutine headers such as "goroutine 1 [running]:"
+	Goroutine *Chalk
+	// Styles of application frames
+	Function *Chalk
+	File     *Chalk
+	Line     *Chalk
+	// Style of standard library and runtime frames, function arguments and program counter offsets
+	Runtime *Chalk
+	// Color level of the output, detected for stdout by default. Traces are returned unchanged with ColorLevelNone
+	Level ColorLevel
+}
+
+// Method to style a Go panic or a stack trace from runtime/debug.Stack. Messages and goroutine headers are
+// highlighted, application frames show their function, file and line in separate styles and frames of the standard
+// library and runtime are dimmed. Lines that aren't part of a trace, such as "exit status 2", are kept as they are.
+// Passing nil options uses the default styles
+//
+//	output, _ := cmd.CombinedOutput()
+//	fmt.Println(gochalk.FormatStack(string(output), nil))
+func FormatStack(trace string, options *StackOptions) string {
+	options = options.withDefaults()
+	lines := strings.Split(trace, "\n")
+
+	message := false
+	stdlib := false
+	for index, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") ||
+			strings.HasPrefix(line, "\tpanic: ") || strings.HasPrefix(line, "[signal "):
+			message = true
+			lines[index] = paintStyle(options.Level, options.Message, line)
+		case line == "":
+			message = false
+		case message:
+			// Continuation of a message with several lines
+			lines[index] = paintStyle(options.Level, options.Message, line)
+		case strings.HasPrefix(line, "goroutine ") && strings.HasSuffix(line, ":"):
+			lines[index] = paintStyle(options.Level, options.Goroutine, line)
+		case strings.HasPrefix(line, "\t") && index > 0 && strings.Contains(line, ":"):
+			lines[index] = options.fileLine(line, stdlib)
+		case index+1 < len(lines) && strings.HasPrefix(lines[index+1], "\t"):
+			name := stackFunction(line)
+			stdlib = stdlibFrame(name, stackFile(lines[index+1]))
+			lines[index] = options.functionLine(line, name, stdlib)
+		case strings.HasPrefix(line, "...") && strings.HasSuffix(line, "..."):
+			// "...additional frames elided..."
+			lines[index] = paintStyle(options.Level, options.Runtime, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Method to recover from a panic in main and print it with its stack trace styled to stderr, then exit with
+// status 2 like an unrecovered panic. It must be deferred directly
+//
+//	func main() {
+//		defer gochalk.RecoverPanic()
+//		run()
+//	}
+func RecoverPanic() {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	writePanic(os.Stderr, value, debug.Stack(), &StackOptions{Level: detectColorLevel(os.Getenv, isTerminal(os.Stderr))})
+	os.Exit(2)
+}
+
+// Method to write a recovered panic value and the stack it was recovered on. Frames before the call to panic,
+// which belong to the deferred function recovering it, are left out
+func writePanic(writer io.Writer, value any, stack []byte, options *StackOptions) {
+	lines := strings.Split(strings.TrimRight(string(stack), "\n"), "\n")
+	for index := 1; index < len(lines); index++ {
+		if strings.HasPrefix(lines[index], "panic(") {
+			lines = append(lines[:1], lines[index:]...)
+			break
+		}
+	}
+
+	trace := fmt.Sprintf("panic: %v\n\n%s\n", value, strings.Join(lines, "\n"))
+	io.WriteString(writer, FormatStack(trace, options))
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *StackOptions) withDefaults() *StackOptions {
+	result := StackOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	fillDefaultStyles([]defaultStyle{
+		{&result.Message, NewStyle(FgRed, Bold)},
+		{&result.Goroutine, NewStyle(FgYellow, Bold)},
+		{&result.Function, NewStyle(Bold)},
+		{&result.File, NewStyle(FgCyan)},
+		{&result.Line, NewStyle(FgYellow)},
+		{&result.Runtime, NewStyle(FgBrightBlack)},
+	})
+
+	return &result
+}
+
+// Method to style the function line of a frame, e.g. "main.run(0x1, {0x4b2f00, 0x3})"
+func (options *StackOptions) functionLine(line string, name string, stdlib bool) string {
+	if stdlib {
+		return paintStyle(options.Level, options.Runtime, line)
+	}
+
+	start := strings.Index(line, name)
+	return line[:start] + paintStyle(options.Level, options.Function, name) + paintStyle(options.Level, options.Runtime, line[start+len(name):])
+}
+
+// Method to style the file line of a frame, e.g. "\t/app/main.go:12 +0x1d"
+func (options *StackOptions) fileLine(line string, stdlib bool) string {
+	if stdlib {
+		return "\t" + paintStyle(options.Level, options.Runtime, line[1:])
+	}
+
+	location, offset, _ := strings.Cut(line[1:], " ")
+	if offset != "" {
+		offset = " " + offset
+	}
+	separator := strings.LastIndex(location, ":")
+	if separator < 0 {
+		return "\t" + paintStyle(options.Level, options.File, location) + paintStyle(options.Level, options.Runtime, offset)
+	}
+
+	return "\t" + paintStyle(options.Level, options.File, location[:separator]) + ":" +
+		paintStyle(options.Level, options.Line, location[separator+1:]) + paintStyle(options.Level, options.Runtime, offset)
+}
+
+// Method to get the function name of a frame line, without its arguments or "created by" prefix
+func stackFunction(line string) string {
+	name := line
+	if created, found := strings.CutPrefix(name, "created by "); found {
+		name, _, _ = strings.Cut(created, " in goroutine ")
+	} else if strings.HasSuffix(name, ")") {
+		// Cut the arguments, finding the parenthesis matching the last one
+		depth := 0
+		for index := len(name) - 1; index >= 0; index-- {
+			if name[index] == ')' {
+				depth++
+			} else if name[index] == '(' {
+				if depth--; depth == 0 {
+					name = name[:index]
+					break
+				}
+			}
+		}
+	}
+
+	return name
+}
+
+// Method to get the source file of a frame from its file line, e.g. "/app/main.go" from "\t/app/main.go:12 +0x1d"
+func stackFile(line string) string {
+	location, _, _ := strings.Cut(strings.TrimPrefix(line, "\t"), " ")
+	if separator := strings.LastIndex(location, ":"); separator >= 0 {
+		location = location[:separator]
+	}
+
+	return filepath.ToSlash(location)
+}
+
+// Method to check if a frame belongs to the standard library or runtime. Frames of main, of the main module of the
+// running program and of packages whose path starts with a domain are application frames. Other frames are
+// standard library if their file is in GOROOT/src, or in a src directory matching their package when the trace comes
+// from another machine. Without an absolute file, such as in programs built with -trimpath, they are standard library
+func stdlibFrame(name string, file string) bool {
+	pkg := stackPackage(name)
+	if pkg == "main" || (stackMainModule != "" && (pkg == stackMainModule || strings.HasPrefix(pkg, stackMainModule+"/"))) {
+		return false
+	}
+	if first, _, _ := strings.Cut(pkg, "/"); strings.Contains(first, ".") {
+		return false
+	}
+	if !path.IsAbs(file) && !filepath.IsAbs(filepath.FromSlash(file)) {
+		return true
+	}
+
+	if root := filepath.ToSlash(runtime.GOROOT()); root != "" && strings.HasPrefix(file, strings.TrimSuffix(root, "/")+"/src/") {
+		return true
+	}
+	return strings.HasSuffix(path.Dir(file), "/src/"+pkg)
+}
+
+// Method to get the package path of a function name, e.g. "net/http" from "net/http.(*conn).serve"
+func stackPackage(name string) string {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+
+	return name
+}
+
+// Path of the main module of the running program, whose frames are always application frames
+var stackMainModule = func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}()
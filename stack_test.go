@@ -0,0 +1,135 @@
+package gochalk
+
+import (
+	"bytes"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+const testPanicTrace = `panic: runtime error: index out of range [5] with length 3
+
+goroutine 1 [running]:
+main.(*Server).handle(0xc000010000, {0x4b2f00, 0x3})
+	/home/dev/app/server.go:42 +0x1d
+github.com/acme/app/router.Dispatch(...)
+	/home/dev/app/router/router.go:17
+net/http.HandlerFunc.ServeHTTP(0x0?, {0x5a1c60?, 0xc0000a2000?}, 0x0?)
+	/usr/local/go/src/net/http/server.go:2136 +0x29
+created by main.main in goroutine 1
+	/home/dev/app/main.go:10 +0x5e
+exit status 2`
+
+func TestFormatStack(t *testing.T) {
+	actualString := FormatStack(testPanicTrace, &StackOptions{Level: ColorLevelNone})
+	if strings.Compare(actualString, testPanicTrace) != 0 {
+		t.Errorf("\nExpected: %s\nActual: %s", testPanicTrace, actualString)
+	}
+
+	options := &StackOptions{Level: ColorLevel16}
+	lines := strings.Split(FormatStack(testPanicTrace, options), "\n")
+	expected := []string{
+		NewStyle(FgRed, Bold).ToString("panic: runtime error: index out of range [5] with length 3"),
+		"",
+		NewStyle(FgYellow, Bold).ToString("goroutine 1 [running]:"),
+		NewStyle(Bold).ToString("main.(*Server).handle") + NewStyle(FgBrightBlack).ToString("(0xc000010000, {0x4b2f00, 0x3})"),
+		"\t" + NewStyle(FgCyan).ToString("/home/dev/app/server.go") + ":" + NewStyle(FgYellow).ToString("42") +
+			NewStyle(FgBrightBlack).ToString(" +0x1d"),
+		NewStyle(Bold).ToString("github.com/acme/app/router.Dispatch") + NewStyle(FgBrightBlack).ToString("(...)"),
+		"\t" + NewStyle(FgCyan).ToString("/home/dev/app/router/router.go") + ":" + NewStyle(FgYellow).ToString("17"),
+		NewStyle(FgBrightBlack).ToString("net/http.HandlerFunc.ServeHTTP(0x0?, {0x5a1c60?, 0xc0000a2000?}, 0x0?)"),
+		"\t" + NewStyle(FgBrightBlack).ToString("/usr/local/go/src/net/http/server.go:2136 +0x29"),
+		"created by " + NewStyle(Bold).ToString("main.main") + NewStyle(FgBrightBlack).ToString(" in goroutine 1"),
+		"\t" + NewStyle(FgCyan).ToString("/home/dev/app/main.go") + ":" + NewStyle(FgYellow).ToString("10") +
+			NewStyle(FgBrightBlack).ToString(" +0x5e"),
+		"exit status 2",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("\nExpected: %d lines\nActual: %d lines", len(expected), len(lines))
+	}
+	for index := range expected {
+		if strings.Compare(lines[index], expected[index]) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", expected[index], lines[index])
+		}
+	}
+}
+
+func TestFormatStack_DomainlessModule(t *testing.T) {
+	trace := `goroutine 1 [running]:
+myapp/internal/server.(*Server).Start(0xc000010000)
+	/home/dev/myapp/internal/server/server.go:31 +0x1d
+net/http.(*conn).serve(0xc0000a2000)
+	/usr/lib/go-1.21/src/net/http/server.go:2009 +0x5f4
+runtime/debug.Stack()
+	runtime/debug/stack.go:26 +0x5e`
+
+	options := &StackOptions{Level: ColorLevel16}
+	lines := strings.Split(FormatStack(trace, options), "\n")
+	expected := []string{
+		NewStyle(FgYellow, Bold).ToString("goroutine 1 [running]:"),
+		NewStyle(Bold).ToString("myapp/internal/server.(*Server).Start") + NewStyle(FgBrightBlack).ToString("(0xc000010000)"),
+		"\t" + NewStyle(FgCyan).ToString("/home/dev/myapp/internal/server/server.go") + ":" + NewStyle(FgYellow).ToString("31") +
+			NewStyle(FgBrightBlack).ToString(" +0x1d"),
+		NewStyle(FgBrightBlack).ToString("net/http.(*conn).serve(0xc0000a2000)"),
+		"\t" + NewStyle(FgBrightBlack).ToString("/usr/lib/go-1.21/src/net/http/server.go:2009 +0x5f4"),
+		NewStyle(FgBrightBlack).ToString("runtime/debug.Stack()"),
+		"\t" + NewStyle(FgBrightBlack).ToString("runtime/debug/stack.go:26 +0x5e"),
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("\nExpected: %d lines\nActual: %d lines", len(expected), len(lines))
+	}
+	for index := range expected {
+		if strings.Compare(lines[index], expected[index]) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", expected[index], lines[index])
+		}
+	}
+}
+
+func TestStdlibFrame_MainModule(t *testing.T) {
+	mainModule := stackMainModule
+	stackMainModule = "myapp"
+	t.Cleanup(func() { stackMainModule = mainModule })
+
+	// Files of programs built with -trimpath are relative, so only the main module tells them apart
+	if stdlibFrame("myapp/internal/server.(*Server).Start", "myapp/internal/server/server.go") {
+		t.Errorf("Expected a frame of the main module to be an application frame")
+	}
+	if !stdlibFrame("runtime/debug.Stack", "runtime/debug/stack.go") {
+		t.Errorf("Expected runtime/debug.Stack to be a standard library frame")
+	}
+}
+
+func TestFormatStack_Debug(t *testing.T) {
+	actualString := FormatStack(string(debug.Stack()), &StackOptions{Level: ColorLevel16})
+
+	expectedString := NewStyle(FgBrightBlack).ToString("runtime/debug.Stack()")
+	if !strings.Contains(actualString, expectedString) {
+		t.Errorf("\nExpected to contain: %q\nActual: %q", expectedString, actualString)
+	}
+	expectedString = NewStyle(Bold).ToString("github.com/shashankbhat10/gochalk.TestFormatStack_Debug")
+	if !strings.Contains(actualString, expectedString) {
+		t.Errorf("\nExpected to contain: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestWritePanic(t *testing.T) {
+	var buffer bytes.Buffer
+	func() {
+		defer func() {
+			writePanic(&buffer, recover(), debug.Stack(), &StackOptions{Level: ColorLevelNone})
+		}()
+		panic("boom")
+	}()
+
+	actualString := buffer.String()
+	if !strings.HasPrefix(actualString, "panic: boom\n\ngoroutine ") {
+		t.Errorf("\nExpected prefix: %q\nActual: %q", "panic: boom\n\ngoroutine ", actualString)
+	}
+	lines := strings.Split(actualString, "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[3], "panic(") {
+		t.Errorf("\nExpected: frames starting at the call to panic\nActual: %q", actualString)
+	}
+	if strings.Contains(actualString, "runtime/debug.Stack") {
+		t.Errorf("\nExpected: no frames of the recovering function\nActual: %q", actualString)
+	}
+}
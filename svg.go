@@ -0,0 +1,251 @@
+package gochalk
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options for rendering styled text as an SVG terminal screenshot
+type SVGOptions struct {
+	// Font family used for the text. Defaults to a monospace font stack
+	FontFamily string
+	// Font size in pixels. Defaults to 14
+	FontSize float64
+	// Line height as a multiple of font size. Defaults to 1.4
+	LineHeight float64
+	// Width of the terminal in columns. Longer lines are wrapped. Defaults to the longest line
+	Columns int
+	// Palette used to resolve basic and 256 colors. Defaults to the palette set using SetPalette
+	Palette *Palette
+	// Default text color. Defaults to palette white
+	Foreground *Color
+	// Terminal background color. Defaults to #1e1e1e
+	Background *Color
+	// Draw a window title bar with buttons around the terminal
+	WindowChrome bool
+	// Title shown in the title bar when WindowChrome is set
+	Title string
+	// Padding around the text in pixels. Defaults to 16
+	Padding float64
+}
+
+// Width of a monospace character relative to font size
+const svgCharWidthRatio = 0.6
+
+// Height of the window title bar in pixels
+const svgChromeHeight = 32.0
+
+// A piece of text sharing the same attributes on a single line
+type svgRun struct {
+	column int
+	text   string
+	width  int
+	state  sgrState
+}
+
+// Method to render text containing SGR escape sequences as a self-contained SVG image of a terminal window.
+// Passing nil options uses the defaults
+//
+//	svg := gochalk.RenderSVG(gochalk.NewStyle(gochalk.FgGreen).ToString("PASS"), &gochalk.SVGOptions{WindowChrome: true})
+func RenderSVG(value string, options *SVGOptions) string {
+	options = options.withDefaults()
+	lines := layoutSVGLines(value, options.Columns)
+
+	columns := options.Columns
+	if columns == 0 {
+		for _, line := range lines {
+			if len(line) > 0 {
+				last := line[len(line)-1]
+				columns = max(columns, last.column+last.width)
+			}
+		}
+	}
+
+	charWidth := options.FontSize * svgCharWidthRatio
+	lineHeight := options.FontSize * options.LineHeight
+	top := options.Padding
+	if options.WindowChrome {
+		top += svgChromeHeight
+	}
+	width := 2*options.Padding + float64(columns)*charWidth
+	height := top + options.Padding + float64(len(lines))*lineHeight
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`,
+		svgNumber(width), svgNumber(height), svgNumber(width), svgNumber(height))
+	builder.WriteString("\n")
+
+	radius := 0.0
+	if options.WindowChrome {
+		radius = 8
+	}
+	fmt.Fprintf(&builder, `<rect width="100%%" height="100%%" rx="%s" fill="%s"/>`, svgNumber(radius), options.Background.Hex())
+	builder.WriteString("\n")
+	if options.WindowChrome {
+		writeSVGChrome(&builder, options, width)
+	}
+
+	fmt.Fprintf(&builder, `<g font-family="%s" font-size="%s" fill="%s">`,
+		html.EscapeString(options.FontFamily), svgNumber(options.FontSize), options.Foreground.Hex())
+	builder.WriteString("\n")
+	for lineIndex, line := range lines {
+		y := top + float64(lineIndex)*lineHeight
+		for _, run := range line {
+			x := options.Padding + float64(run.column)*charWidth
+			fg, bg := run.state.colors()
+			if bg.kind != colorDefault {
+				fmt.Fprintf(&builder, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`,
+					svgNumber(x), svgNumber(y), svgNumber(float64(run.width)*charWidth), svgNumber(lineHeight),
+					bg.resolve(options.Palette).Hex())
+				builder.WriteString("\n")
+			}
+			if run.state.hidden || strings.TrimSpace(run.text) == "" {
+				continue
+			}
+
+			fmt.Fprintf(&builder, `<text x="%s" y="%s" dominant-baseline="middle" xml:space="preserve"`,
+				svgNumber(x), svgNumber(y+lineHeight/2))
+			if fg.kind != colorDefault {
+				fmt.Fprintf(&builder, ` fill="%s"`, fg.resolve(options.Palette).Hex())
+			}
+			if run.width != utf8.RuneCountInString(run.text) {
+				// Fonts rarely draw wide characters exactly two columns wide, so the run is stretched to its columns
+				fmt.Fprintf(&builder, ` textLength="%s" lengthAdjust="spacingAndGlyphs"`, svgNumber(float64(run.width)*charWidth))
+			}
+			builder.WriteString(svgTextAttributes(run.state))
+			fmt.Fprintf(&builder, ">%s</text>\n", html.EscapeString(run.text))
+		}
+	}
+	builder.WriteString("</g>\n</svg>\n")
+
+	return builder.String()
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *SVGOptions) withDefaults() *SVGOptions {
+	result := SVGOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.FontFamily == "" {
+		result.FontFamily = "'SFMono-Regular', Menlo, Monaco, Consolas, 'Courier New', monospace"
+	}
+	if result.FontSize <= 0 {
+		result.FontSize = 14
+	}
+	if result.LineHeight <= 0 {
+		result.LineHeight = 1.4
+	}
+	if result.Palette == nil {
+		palette := CurrentPalette()
+		result.Palette = &palette
+	}
+	if result.Foreground == nil {
+		result.Foreground = &result.Palette[7]
+	}
+	if result.Background == nil {
+		result.Background = &Color{0x1e, 0x1e, 0x1e}
+	}
+	if result.Padding <= 0 {
+		result.Padding = 16
+	}
+
+	return &result
+}
+
+// Method to split styled text into lines of runs. Lines longer than columns are wrapped, tabs are expanded.
+// Wide characters such as CJK and emoji take two columns
+func layoutSVGLines(value string, columns int) [][]svgRun {
+	lines := [][]svgRun{nil}
+	var state sgrState
+	column := 0
+
+	appendText := func(text string, width int) {
+		line := &lines[len(lines)-1]
+		if count := len(*line); count > 0 && (*line)[count-1].state == state {
+			(*line)[count-1].text += text
+			(*line)[count-1].width += width
+		} else {
+			*line = append(*line, svgRun{column: column, text: text, width: width, state: state})
+		}
+		column += width
+	}
+	newLine := func() {
+		lines = append(lines, nil)
+		column = 0
+	}
+
+	for _, token := range Tokenize(value) {
+		switch {
+		case token.Kind == TokenSGR:
+			state.apply(token.Styles)
+		case token.Raw == "\n":
+			newLine()
+		case token.Raw == "\t":
+			appendText(strings.Repeat(" ", 8-column%8), 8-column%8)
+		case token.Kind == TokenText:
+			for _, cluster := range graphemes(token.Raw) {
+				width := graphemeWidth(cluster)
+				if columns > 0 && column > 0 && column+width > columns {
+					newLine()
+				}
+				appendText(cluster, width)
+			}
+		}
+	}
+
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// Method to write window background, title bar buttons and title
+func writeSVGChrome(builder *strings.Builder, options *SVGOptions, width float64) {
+	buttons := []string{"#ff5f56", "#ffbd2e", "#27c93f"}
+	for index, fill := range buttons {
+		fmt.Fprintf(builder, `<circle cx="%d" cy="%s" r="6" fill="%s"/>`, 20+index*20, svgNumber(svgChromeHeight/2), fill)
+		builder.WriteString("\n")
+	}
+	if options.Title != "" {
+		fmt.Fprintf(builder, `<text x="%s" y="%s" text-anchor="middle" dominant-baseline="middle" font-family="%s" font-size="%s" fill="%s" opacity="0.6">%s</text>`,
+			svgNumber(width/2), svgNumber(svgChromeHeight/2), html.EscapeString(options.FontFamily),
+			svgNumber(options.FontSize*0.9), options.Foreground.Hex(), html.EscapeString(options.Title))
+		builder.WriteString("\n")
+	}
+}
+
+// Method to get SVG presentation attributes for text attributes in state
+func svgTextAttributes(state sgrState) string {
+	var attributes string
+	if state.bold {
+		attributes += ` font-weight="bold"`
+	}
+	if state.italic {
+		attributes += ` font-style="italic"`
+	}
+	if state.dim {
+		attributes += ` opacity="0.5"`
+	}
+
+	var decorations []string
+	if state.underline {
+		decorations = append(decorations, "underline")
+	}
+	if state.strikethrough {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		attributes += fmt.Sprintf(` text-decoration="%s"`, strings.Join(decorations, " "))
+	}
+
+	return attributes
+}
+
+// Method to format a number for SVG attributes without trailing zeros
+func svgNumber(value float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
@@ -0,0 +1,76 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSVG_Styles(t *testing.T) {
+	svg := RenderSVG(StyledString("Hi", Bold, FgRed, BgBlue)+" <there>", nil)
+
+	expectedParts := []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="116" height="51.6" viewBox="0 0 116 51.6">`,
+		`<rect x="16" y="16" width="16.8" height="19.6" fill="#0000ee"/>`,
+		`<text x="16" y="25.8" dominant-baseline="middle" xml:space="preserve" fill="#cd0000" font-weight="bold">Hi</text>`,
+		`<text x="32.8" y="25.8" dominant-baseline="middle" xml:space="preserve"> &lt;there&gt;</text>`,
+	}
+	for _, expected := range expectedParts {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("\nExpected: %s\nActual: %s", expected, svg)
+		}
+	}
+}
+
+func TestRenderSVG_Columns(t *testing.T) {
+	svg := RenderSVG("abcdef\nxy", &SVGOptions{Columns: 4, FontSize: 10, LineHeight: 1, Padding: 1})
+
+	expectedParts := []string{
+		`width="26" height="32"`,
+		`y="6" dominant-baseline="middle" xml:space="preserve">abcd</text>`,
+		`y="16" dominant-baseline="middle" xml:space="preserve">ef</text>`,
+		`y="26" dominant-baseline="middle" xml:space="preserve">xy</text>`,
+	}
+	for _, expected := range expectedParts {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("\nExpected: %s\nActual: %s", expected, svg)
+		}
+	}
+}
+
+func TestRenderSVG_WideCharacters(t *testing.T) {
+	svg := RenderSVG("日本語ab\n日本"+Red("x"), &SVGOptions{Columns: 5, FontSize: 10, LineHeight: 1, Padding: 1})
+
+	expectedParts := []string{
+		`width="32" height="32"`,
+		`<text x="1" y="6" dominant-baseline="middle" xml:space="preserve" textLength="24" lengthAdjust="spacingAndGlyphs">日本</text>`,
+		`<text x="1" y="16" dominant-baseline="middle" xml:space="preserve" textLength="24" lengthAdjust="spacingAndGlyphs">語ab</text>`,
+		`<text x="25" y="26" dominant-baseline="middle" xml:space="preserve" fill="#cd0000">x</text>`,
+	}
+	for _, expected := range expectedParts {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("\nExpected: %s\nActual: %s", expected, svg)
+		}
+	}
+}
+
+func TestRenderSVG_WindowChrome(t *testing.T) {
+	svg := RenderSVG("$ go test", &SVGOptions{WindowChrome: true, Title: "bash & co"})
+
+	if !strings.Contains(svg, `<circle cx="20" cy="16" r="6" fill="#ff5f56"/>`) {
+		t.Errorf("\nExpected: Window buttons\nActual: %s", svg)
+	}
+	if !strings.Contains(svg, ">bash &amp; co</text>") {
+		t.Errorf("\nExpected: Escaped window title\nActual: %s", svg)
+	}
+}
+
+func TestSVGNumber(t *testing.T) {
+	values := map[float64]string{16: "16", 0: "0", 10.5: "10.5", 8.123: "8.12"}
+
+	for value, expectedString := range values {
+		actualString := svgNumber(value)
+		if strings.Compare(actualString, expectedString) != 0 {
+			t.Errorf("\nExpected: %s\nActual: %s", expectedString, actualString)
+		}
+	}
+}
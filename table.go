@@ -0,0 +1,328 @@
+package gochalk
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Alignment of text inside a column or box
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// How content wider than the available space is fit
+type Overflow int
+
+const (
+	// Wrap content onto multiple lines
+	OverflowWrap Overflow = iota
+	// Cut content and end it with "…"
+	OverflowTruncate
+)
+
+// Characters used to draw borders of tables and boxes. Empty strings skip the corresponding line or edge
+type Border struct {
+	TopLeft, Top, TopJunction, TopRight             string
+	Left, Vertical, Right                           string
+	MiddleLeft, Middle, Cross, MiddleRight          string
+	BottomLeft, Bottom, BottomJunction, BottomRight string
+
+	// Render a Markdown table. The line under the header marks column alignment with - and :, joined by the
+	// MiddleLeft, Cross and MiddleRight characters. Tables without headers get an empty header row, which Markdown requires.
+	// Every row is kept on one line, so | in cells is escaped as \| and line breaks are written as <br>. MaxWidth is ignored
+	Markdown bool
+}
+
+// Border presets
+var (
+	BorderASCII = Border{
+		TopLeft: "+", Top: "-", TopJunction: "+", TopRight: "+",
+		Left: "|", Vertical: "|", Right: "|",
+		MiddleLeft: "+", Middle: "-", Cross: "+", MiddleRight: "+",
+		BottomLeft: "+", Bottom: "-", BottomJunction: "+", BottomRight: "+",
+	}
+	BorderSingle = Border{
+		TopLeft: "┌", Top: "─", TopJunction: "┬", TopRight: "┐",
+		Left: "│", Vertical: "│", Right: "│",
+		MiddleLeft: "├", Middle: "─", Cross: "┼", MiddleRight: "┤",
+		BottomLeft: "└", Bottom: "─", BottomJunction: "┴", BottomRight: "┘",
+	}
+	BorderRounded = Border{
+		TopLeft: "╭", Top: "─", TopJunction: "┬", TopRight: "╮",
+		Left: "│", Vertical: "│", Right: "│",
+		MiddleLeft: "├", Middle: "─", Cross: "┼", MiddleRight: "┤",
+		BottomLeft: "╰", Bottom: "─", BottomJunction: "┴", BottomRight: "╯",
+	}
+	BorderDouble = Border{
+		TopLeft: "╔", Top: "═", TopJunction: "╦", TopRight: "╗",
+		Left: "║", Vertical: "║", Right: "║",
+		MiddleLeft: "╠", Middle: "═", Cross: "╬", MiddleRight: "╣",
+		BottomLeft: "╚", Bottom: "═", BottomJunction: "╩", BottomRight: "╝",
+	}
+	BorderNone     = Border{}
+	BorderMarkdown = Border{
+		Left: "|", Vertical: "|", Right: "|",
+		MiddleLeft: "|", Middle: "-", Cross: "|", MiddleRight: "|",
+		Markdown: true,
+	}
+)
+
+// Table with styled header, rows and borders. Column widths are measured ignoring escape sequences,
+// so cells can contain strings already styled using gochalk
+type Table struct {
+	Headers []string
+	Rows    [][]string
+	// Alignment of each column. Columns without an alignment are left aligned
+	Alignments []Alignment
+	Border     Border
+	// Styles for border characters, header cells and row cells
+	BorderStyle *Chalk
+	HeaderStyle *Chalk
+	RowStyle    *Chalk
+	// Styles added to RowStyle for every second row, e.g. a Bg* style for alternating backgrounds
+	StripeStyle *Chalk
+	// How cells which don't fit MaxWidth are shortened
+	Overflow Overflow
+	// Maximum width of the table in columns. 0 uses the terminal width when stdout is a terminal, negative means no limit
+	MaxWidth int
+}
+
+// Creates a new Table with the given headers, rounded borders and bold header
+//
+//	table := gochalk.NewTable("Name", "Status")
+//	table.AddRow("api", gochalk.Green("running"))
+//	table.Println()
+func NewTable(headers ...string) *Table {
+	return &Table{
+		Headers:     headers,
+		Border:      BorderRounded,
+		HeaderStyle: NewStyle(Bold),
+	}
+}
+
+// Method to append a row of cells to the table
+func (table *Table) AddRow(cells ...string) *Table {
+	table.Rows = append(table.Rows, cells)
+	return table
+}
+
+// Method to print the rendered table
+func (table *Table) Println() {
+	fmt.Println(table.Render())
+}
+
+// Method to render the table to a string
+func (table *Table) Render() string {
+	if table.Border.Markdown {
+		table = table.markdownCells()
+	}
+
+	widths := table.columnWidths()
+	if len(widths) == 0 {
+		return ""
+	}
+
+	var lines []string
+	border := table.Border
+	if line := table.horizontalLine(widths, border.TopLeft, border.Top, border.TopJunction, border.TopRight); line != "" {
+		lines = append(lines, line)
+	}
+	switch {
+	case border.Markdown:
+		headers := table.Headers
+		if len(headers) == 0 {
+			headers = make([]string, len(widths))
+		}
+		lines = append(lines, table.renderRow(headers, widths, table.HeaderStyle)...)
+		lines = append(lines, table.markdownSeparator(widths))
+	case len(table.Headers) > 0:
+		lines = append(lines, table.renderRow(table.Headers, widths, table.HeaderStyle)...)
+		if line := table.horizontalLine(widths, border.MiddleLeft, border.Middle, border.Cross, border.MiddleRight); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	for index, row := range table.Rows {
+		rowStyle := table.RowStyle
+		if index%2 == 1 && table.StripeStyle != nil {
+			if rowStyle == nil {
+				rowStyle = NewStyle()
+			}
+			rowStyle = rowStyle.Add(table.StripeStyle.styles...)
+		}
+		lines = append(lines, table.renderRow(row, widths, rowStyle)...)
+	}
+	if line := table.horizontalLine(widths, border.BottomLeft, border.Bottom, border.BottomJunction, border.BottomRight); line != "" {
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Method to get a copy of the table for Markdown output, with cells escaped to stay on one line and no width limit
+func (table *Table) markdownCells() *Table {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	escapeCells := func(cells []string) []string {
+		escaped := make([]string, len(cells))
+		for index, cell := range cells {
+			escaped[index] = escape.Replace(cell)
+		}
+		return escaped
+	}
+
+	result := *table
+	result.MaxWidth = -1
+	result.Headers = escapeCells(table.Headers)
+	result.Rows = make([][]string, len(table.Rows))
+	for index, row := range table.Rows {
+		result.Rows[index] = escapeCells(row)
+	}
+
+	return &result
+}
+
+// Method to get the width of every column, shrinking the widest columns until the table fits MaxWidth
+func (table *Table) columnWidths() []int {
+	columns := len(table.Headers)
+	for _, row := range table.Rows {
+		columns = max(columns, len(row))
+	}
+
+	widths := make([]int, columns)
+	measure := func(cells []string) {
+		for index, cell := range cells {
+			for _, line := range strings.Split(cell, "\n") {
+				widths[index] = max(widths[index], StringWidth(line))
+			}
+		}
+	}
+	measure(table.Headers)
+	for _, row := range table.Rows {
+		measure(row)
+	}
+
+	maxWidth := table.MaxWidth
+	if maxWidth == 0 && isTerminal(os.Stdout) {
+		maxWidth = TerminalWidth()
+	}
+	if maxWidth <= 0 || columns == 0 {
+		return widths
+	}
+
+	border := table.Border
+	available := maxWidth - 2*columns - StringWidth(border.Left) - StringWidth(border.Right) - (columns-1)*StringWidth(border.Vertical)
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	for total > available {
+		widest := 0
+		for index, width := range widths {
+			if width > widths[widest] {
+				widest = index
+			}
+		}
+		if widths[widest] <= 1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	return widths
+}
+
+// Method to render the lines of a single row
+func (table *Table) renderRow(cells []string, widths []int, style *Chalk) []string {
+	cellLines := make([][]string, len(widths))
+	height := 1
+	for index, width := range widths {
+		var cell string
+		if index < len(cells) {
+			cell = cells[index]
+		}
+
+		if table.Overflow == OverflowTruncate {
+			cellLines[index] = strings.Split(reapplyStylesPerLine(cell), "\n")
+			for lineIndex, line := range cellLines[index] {
+				cellLines[index][lineIndex] = Truncate(line, width, "…")
+			}
+		} else {
+			cellLines[index] = strings.Split(Wrap(cell, width), "\n")
+		}
+		height = max(height, len(cellLines[index]))
+	}
+
+	lines := make([]string, height)
+	for lineIndex := range lines {
+		parts := make([]string, len(widths))
+		for index, width := range widths {
+			var line string
+			if lineIndex < len(cellLines[index]) {
+				line = cellLines[index][lineIndex]
+			}
+			parts[index] = overlayStyle(style, " "+alignString(line, width, table.alignment(index))+" ")
+		}
+		lines[lineIndex] = table.borderString(table.Border.Left) +
+			strings.Join(parts, table.borderString(table.Border.Vertical)) +
+			table.borderString(table.Border.Right)
+	}
+
+	return lines
+}
+
+// Method to render a horizontal border line. Returns empty string if the border has no such line
+func (table *Table) horizontalLine(widths []int, left, fill, junction, right string) string {
+	if fill == "" {
+		return ""
+	}
+
+	segments := make([]string, len(widths))
+	for index, width := range widths {
+		segments[index] = strings.Repeat(fill, width+2)
+	}
+
+	return table.borderString(left + strings.Join(segments, junction) + right)
+}
+
+// Method to render the markdown line under the header containing alignment markers
+func (table *Table) markdownSeparator(widths []int) string {
+	segments := make([]string, len(widths))
+	for index, width := range widths {
+		dashes := strings.Repeat("-", width+2)
+		switch table.alignment(index) {
+		case AlignCenter:
+			dashes = ":" + dashes[2:] + ":"
+		case AlignRight:
+			dashes = dashes[1:] + ":"
+		default:
+			dashes = ":" + dashes[1:]
+		}
+		segments[index] = dashes
+	}
+
+	border := table.Border
+	return table.borderString(border.MiddleLeft + strings.Join(segments, border.Cross) + border.MiddleRight)
+}
+
+// Method to get the alignment of a column
+func (table *Table) alignment(column int) Alignment {
+	if column < len(table.Alignments) {
+		return table.Alignments[column]
+	}
+
+	return AlignLeft
+}
+
+// Method to style border characters
+func (table *Table) borderString(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return overlayStyle(table.BorderStyle, value)
+}
@@ -0,0 +1,141 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTable_Render(t *testing.T) {
+	table := NewTable("Name", "Status")
+	table.HeaderStyle = nil
+	table.AddRow("api", Green("running")).AddRow("db", Red("down"))
+
+	actualString := table.Render()
+	expectedString := strings.Join([]string{
+		"╭──────┬─────────╮",
+		"│ Name │ Status  │",
+		"├──────┼─────────┤",
+		"│ api  │ " + Green("running") + " │",
+		"│ db   │ " + Red("down") + "    │",
+		"╰──────┴─────────╯",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestTable_AlignmentAndMarkdown(t *testing.T) {
+	table := NewTable("Left", "Center", "Right")
+	table.Border = BorderMarkdown
+	table.Alignments = []Alignment{AlignLeft, AlignCenter, AlignRight}
+	table.AddRow("a", "b", "c")
+
+	actualString := Strip(table.Render())
+	expectedString := strings.Join([]string{
+		"| Left | Center | Right |",
+		"|:-----|:------:|------:|",
+		"| a    |   b    |     c |",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestTable_MarkdownWithoutHeaders(t *testing.T) {
+	table := NewTable()
+	table.Border = BorderMarkdown
+	table.AddRow("a", "bb")
+
+	actualString := Strip(table.Render())
+	expectedString := strings.Join([]string{
+		"|   |    |",
+		"|:--|:---|",
+		"| a | bb |",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestTable_CustomMarkdownBorder(t *testing.T) {
+	table := NewTable("Key", "Value")
+	table.Border = Border{Left: "│", Vertical: "│", Right: "│", MiddleLeft: "│", Middle: "-", Cross: "│", MiddleRight: "│", Markdown: true}
+	table.Alignments = []Alignment{AlignLeft, AlignRight}
+	table.AddRow("a", "1")
+
+	actualString := Strip(table.Render())
+	expectedString := strings.Join([]string{
+		"│ Key │ Value │",
+		"│:----│------:│",
+		"│ a   │     1 │",
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestTable_MaxWidth(t *testing.T) {
+	table := NewTable()
+	table.Border = BorderASCII
+	table.MaxWidth = 16
+	table.AddRow("id", "a long description")
+
+	actualString := table.Render()
+	expectedString := strings.Join([]string{
+		"+----+---------+",
+		"| id | a long  |",
+		"|    | descrip |",
+		"|    | tion    |",
+		"+----+---------+",
+	}, "\n")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+
+	table.Overflow = OverflowTruncate
+	actualString = table.Render()
+	expectedString = strings.Join([]string{
+		"+----+---------+",
+		"| id | a long… |",
+		"+----+---------+",
+	}, "\n")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
+
+func TestTable_Stripes(t *testing.T) {
+	table := NewTable()
+	table.Border = BorderNone
+	table.StripeStyle = NewStyle(BgBrightBlack)
+	table.AddRow("a").AddRow("b")
+
+	actualString := table.Render()
+	expectedString := " a \n" + escapedStyle(BgBrightBlack) + " b " + resetStyle
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestTable_MarkdownEscaping(t *testing.T) {
+	table := NewTable("Flag", "Help")
+	table.Border = BorderMarkdown
+	table.MaxWidth = 10
+	table.AddRow("-a|-b", "first line\nsecond line")
+
+	actualString := Strip(table.Render())
+	expectedString := strings.Join([]string{
+		"| Flag   | Help                      |",
+		"|:-------|:--------------------------|",
+		`| -a\|-b | first line<br>second line |`,
+	}, "\n")
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expectedString, actualString)
+	}
+}
@@ -0,0 +1,157 @@
+package gochalk
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Options for parsing styles and rendering templates
+type TemplateOptions struct {
+	// Styles usable by name, e.g. {error failed}. Theme names take precedence over built-in names
+	Theme Theme
+	// Colors are downsampled to the level and ColorLevelNone writes plain text. Defaults to the level detected for stdout
+	Level ColorLevel
+}
+
+// Names of attributes usable in styles
+var styleAttributeNames = map[string]Style{
+	"bold": Bold, "dim": Dim, "italic": Italics, "italics": Italics, "underline": Underlined, "ul": Underlined,
+	"blink": Blink, "inverse": Inverse, "reverse": Inverse, "hidden": Hidden, "strikethrough": Strikethrough,
+	"strike": Strikethrough,
+}
+
+// Method to parse a style such as "red.bold", "bgBlue white" or "#ff8800 underline". Words are separated by dots or
+// spaces and can be theme names, attributes, color names, numbers from the 256 color range or "#rrggbb".
+// Colors prefixed with "bg" set the background. Passing nil options uses the level detected for stdout
+//
+//	warning, err := gochalk.ParseStyle("yellow.bold", nil)
+func ParseStyle(spec string, options *TemplateOptions) (*Chalk, error) {
+	options = options.withDefaults()
+
+	var styles []Style
+	for _, word := range strings.FieldsFunc(spec, func(char rune) bool { return char == '.' || unicode.IsSpace(char) }) {
+		if chalk, ok := options.Theme[word]; ok {
+			if chalk != nil {
+				styles = append(styles, chalk.styles...)
+			}
+			continue
+		}
+
+		lower := strings.ToLower(word)
+		if attribute, ok := styleAttributeNames[lower]; ok {
+			styles = append(styles, attribute)
+			continue
+		}
+		name, background := strings.CutPrefix(lower, "bg")
+		if !background {
+			name = lower
+		}
+		if name == "gray" || name == "grey" {
+			name = "brightblack"
+		}
+		color, ok := parseGitColorWord(name)
+		if !ok || color == 0 {
+			return nil, fmt.Errorf("gochalk: invalid style %q: unknown word %q", spec, word)
+		}
+		if background {
+			color = backgroundStyle(color)
+		}
+		styles = append(styles, color)
+	}
+
+	if options.Level == ColorLevelNone {
+		return NewStyle(), nil
+	}
+	for index, style := range styles {
+		styles[index] = downsampleStyle(style, options.Level)
+	}
+
+	return NewStyle(styles...), nil
+}
+
+// Method to render a template such as "{red.bold Error:} {dim file not found}". Braces style the text they enclose
+// using a style read by ParseStyle, up to the first space. Templates can be nested, inner styles adding to the outer
+// ones. Braces and backslashes are written literally when escaped with a backslash. Passing nil options uses the
+// level detected for stdout
+//
+//	message, err := gochalk.RenderTemplate("{green ✔} deployed {bold {cyan v1.4.2}} in 12s", nil)
+func RenderTemplate(template string, options *TemplateOptions) (string, error) {
+	options = options.withDefaults()
+
+	var builder, text strings.Builder
+	styles := []*Chalk{NewStyle()}
+	flush := func() {
+		if text.Len() > 0 {
+			builder.WriteString(overlayStyle(styles[len(styles)-1], text.String()))
+			text.Reset()
+		}
+	}
+
+	for position := 0; position < len(template); position++ {
+		switch char := template[position]; char {
+		case '\\':
+			if position+1 < len(template) && strings.IndexByte(`\{}`, template[position+1]) >= 0 {
+				position++
+			}
+			text.WriteByte(template[position])
+		case '{':
+			end := strings.IndexFunc(template[position+1:], func(char rune) bool {
+				return unicode.IsSpace(char) || char == '{' || char == '}'
+			})
+			if end <= 0 || !unicode.IsSpace(rune(template[position+1+end])) {
+				return "", fmt.Errorf("gochalk: invalid template: missing style at offset %d", position)
+			}
+			chalk, err := ParseStyle(template[position+1:position+1+end], options)
+			if err != nil {
+				return "", fmt.Errorf("gochalk: invalid template at offset %d: %w", position, err)
+			}
+			flush()
+			styles = append(styles, styles[len(styles)-1].Add(chalk.styles...))
+			// The space after the style separates it from the text
+			position += end + 1
+		case '}':
+			if len(styles) == 1 {
+				return "", fmt.Errorf("gochalk: invalid template: unexpected } at offset %d", position)
+			}
+			flush()
+			styles = styles[:len(styles)-1]
+		default:
+			text.WriteByte(char)
+		}
+	}
+	if len(styles) > 1 {
+		return "", fmt.Errorf("gochalk: invalid template: %d unclosed {", len(styles)-1)
+	}
+	flush()
+
+	return builder.String(), nil
+}
+
+// Method to return a copy of options with defaults filled in
+func (options *TemplateOptions) withDefaults() *TemplateOptions {
+	result := TemplateOptions{}
+	if options != nil {
+		result = *options
+	}
+	if result.Level == ColorLevelAuto {
+		result.Level = DetectColorLevel()
+	}
+
+	return &result
+}
+
+// Method to replace 256 and 24-bit colors with the closest colors available at level
+func downsampleStyle(style Style, level ColorLevel) Style {
+	kind := style >> styleKindShift
+	background := kind == styleKindBg256 || kind == styleKindBgRGB
+	switch {
+	case (kind == styleKindFg256 || kind == styleKindBg256) && level < ColorLevel256:
+		palette := CurrentPalette()
+		return colorStyle(palette.Color256(uint8(style&styleValueMask)), level, background)
+	case (kind == styleKindFgRGB || kind == styleKindBgRGB) && level < ColorLevelTrueColor:
+		return colorStyle(styleRGB(style), level, background)
+	}
+
+	return style
+}
@@ -0,0 +1,100 @@
+package gochalk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected *Chalk
+	}{
+		{"red.bold", NewStyle(FgRed, Bold)},
+		{"bgBlue  white underline", NewStyle(BgBlue, FgWhite, Underlined)},
+		{"gray.strike", NewStyle(FgBrightBlack, Strikethrough)},
+		{"208 bg#102030", NewStyle(Fg256(208), BgRGB(16, 32, 48))},
+		{"error", NewStyle(FgRed, Bold, Underlined)},
+	}
+	options := &TemplateOptions{Theme: Theme{"error": NewStyle(FgRed, Bold, Underlined)}, Level: ColorLevelTrueColor}
+	for _, test := range tests {
+		chalk, err := ParseStyle(test.spec, options)
+		if err != nil {
+			t.Fatalf("ParseStyle(%q) returned error: %v", test.spec, err)
+		}
+		actualString, expectedString := chalk.ToString("text"), test.expected.ToString("text")
+		if strings.Compare(actualString, expectedString) != 0 {
+			t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+		}
+	}
+
+	if _, err := ParseStyle("red.shiny", options); err == nil {
+		t.Errorf("Expected an error for an unknown word")
+	}
+}
+
+func TestParseStyle_Level(t *testing.T) {
+	chalk, err := ParseStyle("#ff0000.bg208", &TemplateOptions{Level: ColorLevel256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	actualString, expectedString := chalk.ToString("text"), NewStyle(Fg256(196), Bg256(208)).ToString("text")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	chalk, err = ParseStyle("#ff0000.bg208", &TemplateOptions{Level: ColorLevel16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	actualString, expectedString = chalk.ToString("text"), NewStyle(FgBrightRed, BgYellow).ToString("text")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	chalk, err = ParseStyle("red.bold", &TemplateOptions{Level: ColorLevelNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actualString := chalk.ToString("text"); actualString != "text" {
+		t.Errorf("\nExpected: %q\nActual: %q", "text", actualString)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	options := &TemplateOptions{Level: ColorLevelTrueColor}
+	actualString, err := RenderTemplate("{red Error} {dim.italic msg}", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedString := NewStyle(FgRed).ToString("Error") + " " + NewStyle(Dim, Italics).ToString("msg")
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString, err = RenderTemplate("{bold a {red b} c} \\{d\\} \\e", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedString = NewStyle(Bold).ToString("a ") + NewStyle(Bold, FgRed).ToString("b") + NewStyle(Bold).ToString(" c") +
+		" {d} \\e"
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+
+	actualString, err = RenderTemplate("{red Error} {dim msg}", &TemplateOptions{Level: ColorLevelNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedString := "Error msg"; strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestRenderTemplate_Errors(t *testing.T) {
+	for _, template := range []string{"{red", "{red text", "text}", "{}", "{ text}", "{red}", "{shiny text}"} {
+		if _, err := RenderTemplate(template, &TemplateOptions{Level: ColorLevel16}); err == nil {
+			t.Errorf("Expected an error for %q", template)
+		}
+	}
+}
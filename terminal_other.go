@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package gochalk
+
+import "os"
+
+// Method to get the number of columns of the terminal connected to file. Not supported on this platform, always returns 0
+func terminalColumns(file *os.File) int {
+	return 0
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package gochalk
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Method to get the number of columns of the terminal connected to file. Returns 0 if it can't be determined
+func terminalColumns(file *os.File) int {
+	var size struct {
+		rows, columns, xPixels, yPixels uint16
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+
+	return int(size.columns)
+}
@@ -0,0 +1,188 @@
+package gochalk
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind of a token returned by Tokenize
+type TokenKind int
+
+const (
+	// Run of printable text
+	TokenText TokenKind = iota
+	// SGR sequence (ESC [ ... m). Parsed styles are available in Token.Styles
+	TokenSGR
+	// Any other CSI sequence (ESC [ ... final byte), such as cursor movement
+	TokenCSI
+	// Operating system command (ESC ] ... ST), such as hyperlinks and window titles
+	TokenOSC
+	// Device control string (ESC P ... ST). SOS, PM and APC strings are reported as DCS as well
+	TokenDCS
+	// Other escape sequence (ESC followed by intermediate and final bytes), such as ESC 7
+	TokenEscape
+	// Single C0 or C1 control character, including newline and tab
+	TokenControl
+)
+
+// A single piece of a tokenized string
+type Token struct {
+	Kind TokenKind
+	// Token exactly as it appeared in the input
+	Raw string
+	// Parameter and intermediate bytes of CSI and escape sequences, or payload of OSC and DCS strings
+	Params string
+	// Final byte of CSI and escape sequences. 0 if the sequence was cut off
+	Final byte
+	// Styles set by an SGR token. "ESC[m" and "ESC[0m" give Reset. Malformed extended colors are skipped
+	Styles []Style
+}
+
+// Method to check if token is an OSC 8 hyperlink. Returns the link target and the optional id parameter.
+// An empty url marks the end of a hyperlink
+func (token Token) Hyperlink() (url string, id string, ok bool) {
+	if token.Kind != TokenOSC {
+		return "", "", false
+	}
+
+	fields := strings.SplitN(token.Params, ";", 3)
+	if len(fields) != 3 || fields[0] != "8" {
+		return "", "", false
+	}
+	for _, param := range strings.Split(fields[1], ":") {
+		if value, found := strings.CutPrefix(param, "id="); found {
+			id = value
+		}
+	}
+
+	return fields[2], id, true
+}
+
+// Method to split a string into text, escape sequences and control characters.
+// Input is never rejected: cut off sequences are returned with what was read, unterminated strings run to the end
+// and invalid UTF-8 is returned as text. Joining Raw of all tokens gives back the input.
+//
+//	for _, token := range gochalk.Tokenize(gochalk.Red("Error")) {
+//		fmt.Println(token.Kind, token.Styles) // TokenSGR [31], TokenText [], TokenSGR [0]
+//	}
+func Tokenize(value string) []Token {
+	var tokens []Token
+	textStart := 0
+
+	flushText := func(end int) {
+		if end > textStart {
+			tokens = append(tokens, Token{Kind: TokenText, Raw: value[textStart:end]})
+		}
+	}
+
+	for index := 0; index < len(value); {
+		char := value[index]
+		if char == 0x1b {
+			flushText(index)
+			token := readEscape(value, index)
+			tokens = append(tokens, token)
+			index += len(token.Raw)
+			textStart = index
+			continue
+		}
+
+		size := 1
+		isControl := char < 0x20 || char == 0x7f
+		if char >= utf8.RuneSelf {
+			var r rune
+			r, size = utf8.DecodeRuneInString(value[index:])
+			isControl = r >= 0x80 && r < 0xa0
+		}
+		if isControl {
+			flushText(index)
+			tokens = append(tokens, Token{Kind: TokenControl, Raw: value[index : index+size]})
+			textStart = index + size
+		}
+		index += size
+	}
+	flushText(len(value))
+
+	return tokens
+}
+
+// Method to remove all escape sequences and control characters other than newline and tab from a string
+//
+//	gochalk.Strip(gochalk.Red("Error")) // "Error"
+func Strip(value string) string {
+	var builder strings.Builder
+	for _, token := range Tokenize(value) {
+		if token.Kind == TokenText || token.Raw == "\n" || token.Raw == "\t" {
+			builder.WriteString(token.Raw)
+		}
+	}
+
+	return builder.String()
+}
+
+// Method to read the escape sequence starting with the ESC at given index
+func readEscape(value string, start int) Token {
+	if start+1 >= len(value) {
+		return Token{Kind: TokenControl, Raw: value[start:]}
+	}
+
+	switch value[start+1] {
+	case '[':
+		return readCSI(value, start)
+	case ']':
+		payload, end := readStringTerminated(value, start+2)
+		return Token{Kind: TokenOSC, Raw: value[start:end], Params: payload}
+	case 'P', 'X', '^', '_':
+		payload, end := readStringTerminated(value, start+2)
+		return Token{Kind: TokenDCS, Raw: value[start:end], Params: payload}
+	}
+
+	end := start + 1
+	for end < len(value) && value[end] >= 0x20 && value[end] < 0x30 {
+		end++
+	}
+	if end < len(value) && value[end] >= 0x30 && value[end] < 0x7f {
+		return Token{Kind: TokenEscape, Raw: value[start : end+1], Params: value[start+1 : end], Final: value[end]}
+	}
+
+	return Token{Kind: TokenEscape, Raw: value[start:end], Params: value[start+1 : end]}
+}
+
+// Method to read the CSI sequence starting at given index
+func readCSI(value string, start int) Token {
+	end := start + 2
+	for end < len(value) && value[end] >= 0x20 && value[end] < 0x40 {
+		end++
+	}
+	params := value[start+2 : end]
+
+	if end >= len(value) || value[end] < 0x40 || value[end] > 0x7e {
+		return Token{Kind: TokenCSI, Raw: value[start:end], Params: params}
+	}
+
+	token := Token{Kind: TokenCSI, Raw: value[start : end+1], Params: params, Final: value[end]}
+	if token.Final == 'm' {
+		token.Kind = TokenSGR
+		token.Styles = parseSGRStyles(params)
+	}
+
+	return token
+}
+
+// Method to read the payload of an OSC / DCS string starting at given index. The string ends with BEL or ST.
+// An ESC not starting ST also ends the string without being consumed. Returns the payload and the index after the string
+func readStringTerminated(value string, start int) (string, int) {
+	for index := start; index < len(value); index++ {
+		switch {
+		case value[index] == 0x07:
+			return value[start:index], index + 1
+		case value[index] == 0x1b && index+1 < len(value) && value[index+1] == '\\':
+			return value[start:index], index + 2
+		case value[index] == 0x1b:
+			return value[start:index], index
+		case strings.HasPrefix(value[index:], "\u009c"):
+			return value[start:index], index + len("\u009c")
+		}
+	}
+
+	return value[start:], len(value)
+}
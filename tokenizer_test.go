@@ -0,0 +1,99 @@
+package gochalk
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTokenize_StyledString(t *testing.T) {
+	tokens := Tokenize(StyledString(testString, Bold, FgRed))
+
+	if len(tokens) != 3 {
+		t.Fatalf("\nExpected: %d tokens\nActual: %d tokens", 3, len(tokens))
+	}
+	if tokens[0].Kind != TokenSGR || !slices.Equal(tokens[0].Styles, []Style{Bold, FgRed}) {
+		t.Errorf("\nExpected: SGR token with %v\nActual: %v", []Style{Bold, FgRed}, tokens[0])
+	}
+	if tokens[1].Kind != TokenText || tokens[1].Raw != testString {
+		t.Errorf("\nExpected: Text token with %s\nActual: %v", testString, tokens[1])
+	}
+	if tokens[2].Kind != TokenSGR || !slices.Equal(tokens[2].Styles, []Style{Reset}) {
+		t.Errorf("\nExpected: SGR token with %v\nActual: %v", []Style{Reset}, tokens[2])
+	}
+}
+
+func TestTokenize_ExtendedColors(t *testing.T) {
+	tokens := Tokenize("\x1b[38;5;208;48:2::1:2:3;4m\x1b[m\x1b[38;5m")
+
+	expected := [][]Style{{Fg256(208), BgRGB(1, 2, 3), Underlined}, {Reset}, {}}
+	for index, styles := range expected {
+		if !slices.Equal(tokens[index].Styles, styles) {
+			t.Errorf("\nExpected: %v\nActual: %v", styles, tokens[index].Styles)
+		}
+	}
+}
+
+func TestTokenize_Kinds(t *testing.T) {
+	value := "a\x1b[2K\x1b]0;title\x07\x1bPq#0\x1b\\\x1b7\tb\u009b\x1b"
+	tokens := Tokenize(value)
+
+	expectedKinds := []TokenKind{TokenText, TokenCSI, TokenOSC, TokenDCS, TokenEscape, TokenControl, TokenText, TokenControl, TokenControl}
+	var actualKinds []TokenKind
+	var raw string
+	for _, token := range tokens {
+		actualKinds = append(actualKinds, token.Kind)
+		raw += token.Raw
+	}
+
+	if !slices.Equal(actualKinds, expectedKinds) {
+		t.Errorf("\nExpected: %v\nActual: %v", expectedKinds, actualKinds)
+	}
+	if strings.Compare(raw, value) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", value, raw)
+	}
+	if tokens[1].Params != "2" || tokens[1].Final != 'K' {
+		t.Errorf("\nExpected: CSI with params 2 and final K\nActual: %v", tokens[1])
+	}
+	if tokens[2].Params != "0;title" {
+		t.Errorf("\nExpected: %s\nActual: %s", "0;title", tokens[2].Params)
+	}
+}
+
+func TestTokenize_Malformed(t *testing.T) {
+	tokens := Tokenize("x\x1b[31\x1b]8;;http://a\x1b[0mz\xff")
+
+	expectedKinds := []TokenKind{TokenText, TokenCSI, TokenOSC, TokenSGR, TokenText}
+	var actualKinds []TokenKind
+	for _, token := range tokens {
+		actualKinds = append(actualKinds, token.Kind)
+	}
+
+	if !slices.Equal(actualKinds, expectedKinds) {
+		t.Errorf("\nExpected: %v\nActual: %v", expectedKinds, actualKinds)
+	}
+	if tokens[1].Final != 0 {
+		t.Errorf("\nExpected: Cut off CSI without final byte\nActual: %q", tokens[1].Final)
+	}
+}
+
+func TestToken_Hyperlink(t *testing.T) {
+	tokens := Tokenize("\x1b]8;id=42;https://example.com\x1b\\")
+
+	url, id, ok := tokens[0].Hyperlink()
+	if !ok || url != "https://example.com" || id != "42" {
+		t.Errorf("\nExpected: %s %s\nActual: %s %s", "https://example.com", "42", url, id)
+	}
+	if _, _, ok := Tokenize("text")[0].Hyperlink(); ok {
+		t.Error("\nExpected: Text token should not be a hyperlink\nActual: Text token was a hyperlink")
+	}
+}
+
+func TestStrip(t *testing.T) {
+	actualString := Strip(Green("Green", Red("Red"), "String") + "\x1b[2K\r\n\tx")
+	expectedString := "Green Red String\n\tx"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
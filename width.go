@@ -0,0 +1,327 @@
+package gochalk
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ranges of code points displayed using two terminal columns
+var wideRanges = []struct{ first, last rune }{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec}, {0x23f0, 0x23f0}, {0x23f3, 0x23f3},
+	{0x25fd, 0x25fe}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce}, {0x26d4, 0x26d4}, {0x26ea, 0x26ea},
+	{0x26f2, 0x26f3}, {0x26f5, 0x26f5}, {0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27b0, 0x27b0}, {0x27bf, 0x27bf}, {0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf}, {0xa960, 0xa97f}, {0xac00, 0xd7a3},
+	{0xf900, 0xfaff}, {0xfe10, 0xfe19}, {0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x18aff},
+	{0x1b000, 0x1b2ff}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a},
+	{0x1f1e6, 0x1f1ff}, {0x1f200, 0x1f2ff}, {0x1f300, 0x1f64f}, {0x1f680, 0x1f6ff}, {0x1f7e0, 0x1f7eb},
+	{0x1f90c, 0x1f9ff}, {0x1fa70, 0x1faff}, {0x20000, 0x3fffd},
+}
+
+// Method to get the number of terminal columns used by a string. Escape sequences and control characters
+// take no space, wide characters such as CJK and emoji take two columns.
+//
+//	gochalk.StringWidth(gochalk.Red("日本")) // 4
+func StringWidth(value string) int {
+	width := 0
+	for _, token := range Tokenize(value) {
+		if token.Kind == TokenText {
+			for _, cluster := range graphemes(token.Raw) {
+				width += graphemeWidth(cluster)
+			}
+		}
+	}
+
+	return width
+}
+
+// Method to shorten a string to at most width columns, ending it with tail when cut. Escape sequences are kept
+// and a reset is added if the string was cut while styled. A width of zero or less gives an empty string.
+//
+//	gochalk.Truncate(gochalk.Red("Hello World"), 8, "…") // Red "Hello W…"
+func Truncate(value string, width int, tail string) string {
+	if StringWidth(value) <= width {
+		return value
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	limit := width - StringWidth(tail)
+	if limit < 0 {
+		return Truncate(tail, width, "")
+	}
+
+	var builder strings.Builder
+	var state sgrState
+	used := 0
+	for _, token := range Tokenize(value) {
+		switch token.Kind {
+		case TokenSGR:
+			builder.WriteString(token.Raw)
+			state.apply(token.Styles)
+			continue
+		case TokenText:
+		default:
+			builder.WriteString(token.Raw)
+			continue
+		}
+
+		for _, cluster := range graphemes(token.Raw) {
+			clusterWidth := graphemeWidth(cluster)
+			if used+clusterWidth > limit {
+				builder.WriteString(tail)
+				if !state.isDefault() {
+					builder.WriteString(resetStyle)
+				}
+				return builder.String()
+			}
+			builder.WriteString(cluster)
+			used += clusterWidth
+		}
+	}
+
+	return builder.String()
+}
+
+// Method to word wrap a string to lines of at most width columns. Words longer than width are split.
+// Styles active at the end of a line are reset and applied again at the start of the next line
+// so each line can be printed on its own.
+//
+//	gochalk.Wrap(gochalk.Green("a long green sentence"), 10)
+func Wrap(value string, width int) string {
+	if width <= 0 {
+		return value
+	}
+
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		lines = append(lines, wrapLine(line, width)...)
+	}
+
+	return reapplyStylesPerLine(strings.Join(lines, "\n"))
+}
+
+// Method to wrap a single line without newlines
+func wrapLine(line string, width int) []string {
+	var lines []string
+	var current, word strings.Builder
+	currentWidth, wordWidth := 0, 0
+
+	flushWord := func() {
+		if wordWidth == 0 && word.Len() == 0 {
+			return
+		}
+		if currentWidth > 0 && currentWidth+1+wordWidth > width {
+			lines = append(lines, current.String())
+			current.Reset()
+			currentWidth = 0
+		} else if currentWidth > 0 {
+			current.WriteString(" ")
+			currentWidth++
+		}
+		current.WriteString(word.String())
+		currentWidth += wordWidth
+		word.Reset()
+		wordWidth = 0
+	}
+
+	for _, token := range Tokenize(line) {
+		if token.Kind != TokenText {
+			word.WriteString(token.Raw)
+			continue
+		}
+
+		for _, cluster := range graphemes(token.Raw) {
+			if cluster == " " {
+				flushWord()
+				continue
+			}
+
+			clusterWidth := graphemeWidth(cluster)
+			if wordWidth > 0 && wordWidth+clusterWidth > width {
+				flushWord()
+				lines = append(lines, current.String())
+				current.Reset()
+				currentWidth = 0
+			}
+			word.WriteString(cluster)
+			wordWidth += clusterWidth
+		}
+	}
+	flushWord()
+
+	return append(lines, current.String())
+}
+
+// Method to make each line of a multi-line string carry its own styles. Active styles are reset at the
+// end of a line and the SGR sequences which set them are repeated at the start of the next line
+func reapplyStylesPerLine(value string) string {
+	var builder strings.Builder
+	var state sgrState
+	var active []string
+
+	for _, token := range Tokenize(value) {
+		switch {
+		case token.Kind == TokenSGR:
+			state.apply(token.Styles)
+			if state.isDefault() {
+				active = nil
+			} else if isResetToken(token) {
+				active = []string{token.Raw}
+			} else {
+				active = append(active, token.Raw)
+			}
+		case token.Raw == "\n" && len(active) > 0:
+			builder.WriteString(resetStyle + "\n" + strings.Join(active, ""))
+			continue
+		}
+		builder.WriteString(token.Raw)
+	}
+
+	return builder.String()
+}
+
+// Method to wrap a string in styles of chalk. Styles are applied again after every reset inside the string,
+// so backgrounds and colors of chalk stay visible around nested styled strings
+func overlayStyle(chalk *Chalk, value string) string {
+	if chalk == nil || len(chalk.styles) == 0 {
+		return value
+	}
+
+	start := escapedStyles(convertIntSliceToString(chalk.styles))
+	var builder strings.Builder
+	builder.WriteString(start)
+	for _, token := range Tokenize(value) {
+		if token.Kind != TokenSGR || !isResetToken(token) {
+			builder.WriteString(token.Raw)
+			continue
+		}
+
+		// Styles following the last reset in the sequence still need to be applied on top of chalk
+		lastReset := len(token.Styles) - 1
+		for token.Styles[lastReset] != Reset {
+			lastReset--
+		}
+		builder.WriteString(resetStyle + start)
+		if lastReset < len(token.Styles)-1 {
+			builder.WriteString(escapedStyles(convertIntSliceToString(token.Styles[lastReset+1:])))
+		}
+	}
+	builder.WriteString(resetStyle)
+
+	return builder.String()
+}
+
+// Method to add spaces to a string until it is width columns wide, placing text according to alignment
+func alignString(value string, width int, alignment Alignment) string {
+	space := width - StringWidth(value)
+	if space <= 0 {
+		return value
+	}
+
+	switch alignment {
+	case AlignRight:
+		return strings.Repeat(" ", space) + value
+	case AlignCenter:
+		return strings.Repeat(" ", space/2) + value + strings.Repeat(" ", space-space/2)
+	default:
+		return value + strings.Repeat(" ", space)
+	}
+}
+
+// Method to check if an SGR token resets all styles
+func isResetToken(token Token) bool {
+	return slices.Contains(token.Styles, Reset)
+}
+
+// Method to split text into user-perceived characters. Combining marks, variation selectors, emoji modifiers,
+// zero width joiner sequences and regional indicator pairs are kept with the character they belong to
+func graphemes(value string) []string {
+	var clusters []string
+	start := 0
+	var previous rune
+	regionalCount := 0
+
+	for index, char := range value {
+		if index == 0 {
+			previous = char
+			if isRegionalIndicator(char) {
+				regionalCount = 1
+			}
+			continue
+		}
+
+		extend := isGraphemeExtend(char) || previous == 0x200d ||
+			(previous == '\r' && char == '\n') ||
+			(isRegionalIndicator(char) && regionalCount%2 == 1)
+		if isRegionalIndicator(char) {
+			regionalCount++
+		} else {
+			regionalCount = 0
+		}
+
+		if !extend {
+			clusters = append(clusters, value[start:index])
+			start = index
+			if isRegionalIndicator(char) {
+				regionalCount = 1
+			}
+		}
+		previous = char
+	}
+	if start < len(value) {
+		clusters = append(clusters, value[start:])
+	}
+
+	return clusters
+}
+
+// Method to get the number of columns used by a grapheme cluster
+func graphemeWidth(cluster string) int {
+	first, size := utf8.DecodeRuneInString(cluster)
+	if strings.ContainsRune(cluster[size:], 0xfe0f) {
+		return 2
+	}
+
+	return runeWidth(first)
+}
+
+// Method to get the number of columns used by a single rune
+func runeWidth(char rune) int {
+	switch {
+	case char < 0x20 || (char >= 0x7f && char < 0xa0):
+		return 0
+	case char < 0x300:
+		return 1
+	case isGraphemeExtend(char):
+		return 0
+	}
+
+	for _, wide := range wideRanges {
+		if char < wide.first {
+			return 1
+		}
+		if char <= wide.last {
+			return 2
+		}
+	}
+
+	return 1
+}
+
+// Method to check if a rune extends the previous grapheme cluster
+func isGraphemeExtend(char rune) bool {
+	return unicode.In(char, unicode.Mn, unicode.Me, unicode.Mc) ||
+		char == 0x200d || (char >= 0xfe00 && char <= 0xfe0f) || (char >= 0x1f3fb && char <= 0x1f3ff) ||
+		(char >= 0xe0020 && char <= 0xe007f)
+}
+
+// Method to check if a rune is a regional indicator used in flag emoji
+func isRegionalIndicator(char rune) bool {
+	return char >= 0x1f1e6 && char <= 0x1f1ff
+}
@@ -0,0 +1,87 @@
+package gochalk
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStringWidth(t *testing.T) {
+	values := map[string]int{
+		Red("Test String"):        11,
+		Green("日本語"):              6,
+		"é":                      1,
+		"👍🏽 ok":                   5,
+		"\x1b]8;;https://a\x07ab": 2,
+	}
+
+	for value, expected := range values {
+		if actual := StringWidth(value); actual != expected {
+			t.Errorf("\nExpected: %d for %q\nActual: %d", expected, value, actual)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	actualString := Truncate(Red("Hello World"), 8, "…")
+	expectedString := escapedStyle(FgRed) + "Hello W…" + resetStyle
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+	if actualString := Truncate("日本語", 5, ""); actualString != "日本" {
+		t.Errorf("\nExpected: %q\nActual: %q", "日本", actualString)
+	}
+	if actualString := Truncate("short", 8, "…"); actualString != "short" {
+		t.Errorf("\nExpected: %q\nActual: %q", "short", actualString)
+	}
+}
+
+func TestTruncate_NonPositiveWidth(t *testing.T) {
+	for _, width := range []int{0, -1, -10} {
+		if actualString := Truncate(Red("hello"), width, "…"); actualString != "" {
+			t.Errorf("\nExpected: %q for width %d\nActual: %q", "", width, actualString)
+		}
+	}
+	if actualString := Truncate("hello", 1, "…"); actualString != "…" {
+		t.Errorf("\nExpected: %q\nActual: %q", "…", actualString)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	actualString := Wrap(Green("a long green sentence"), 10)
+	green := escapedStyle(FgGreen)
+	expectedString := green + "a long" + resetStyle + "\n" + green + "green" + resetStyle + "\n" + green + "sentence" + resetStyle
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestWrap_LongWord(t *testing.T) {
+	actualString := Wrap("abcdefgh ij", 3)
+	expectedString := "abc\ndef\ngh\nij"
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestOverlayStyle(t *testing.T) {
+	actualString := overlayStyle(NewStyle(BgBlue), "a "+Red("b")+" c")
+	bg := escapedStyle(BgBlue)
+	expectedString := bg + "a " + escapedStyle(FgRed) + "b" + resetStyle + bg + " c" + resetStyle
+
+	if strings.Compare(actualString, expectedString) != 0 {
+		t.Errorf("\nExpected: %q\nActual: %q", expectedString, actualString)
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	actual := graphemes("é👍🏽🇩🇪🇫🇷👨‍👩‍👧x")
+	expected := []string{"é", "👍🏽", "🇩🇪", "🇫🇷", "👨‍👩‍👧", "x"}
+
+	if !slices.Equal(actual, expected) {
+		t.Errorf("\nExpected: %q\nActual: %q", expected, actual)
+	}
+}